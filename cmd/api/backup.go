@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"pull_requests_service/internal/config"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/handler"
+	"pull_requests_service/internal/repository"
+	"pull_requests_service/internal/service"
+)
+
+func runExport(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	output := flags.String("o", "-", "Archive file to write, - for stdout")
+	flags.Parse(args)
+
+	backupService, closeDB, err := newBackupService(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	archive, err := backupService.Export(ctx)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(handler.ArchiveToDTO(archive)); err != nil {
+		return err
+	}
+
+	slog.Info("Exported archive", "teams", len(archive.Teams), "users", len(archive.Users), "pull_requests", len(archive.PullRequests))
+	return nil
+}
+
+func runRestore(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: api restore FILE")
+	}
+
+	var input io.Reader = os.Stdin
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	var req dto.Archive
+	if err := json.NewDecoder(input).Decode(&req); err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+
+	backupService, closeDB, err := newBackupService(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	archive := handler.ArchiveFromDTO(&req)
+	if err := backupService.Restore(ctx, archive); err != nil {
+		return err
+	}
+
+	slog.Info("Restored archive", "teams", len(archive.Teams), "users", len(archive.Users), "pull_requests", len(archive.PullRequests))
+	return nil
+}
+
+func newBackupService(ctx context.Context, cfg *config.Config) (*service.BackupService, func() error, error) {
+	if err := migrateOnStart(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	db, err := openDB(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return service.NewBackupService(repository.NewBackupRepository(db, cfg.DBTimeout)), db.Close, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"pull_requests_service/internal/config"
+	"pull_requests_service/internal/repository"
+	"pull_requests_service/internal/service"
+	"syscall"
+)
+
+func runCommand(cfg *config.Config, args []string) error {
+	// Interrupting a command cancels its in-flight queries.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch args[0] {
+	case "migrate":
+		return runMigrate(cfg, args[1:])
+	case "import":
+		return runImport(ctx, cfg, args[1:])
+	case "export":
+		return runExport(ctx, cfg, args[1:])
+	case "restore":
+		return runRestore(ctx, cfg, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// migrateOnStart brings the schema up to date unless auto-migration has been
+// turned off with -skip-migrations.
+func migrateOnStart(cfg *config.Config) error {
+	if cfg.SkipMigrations {
+		slog.Info("Skipping migrations")
+		return nil
+	}
+	return service.RunMigrations(cfg)
+}
+
+func openDB(ctx context.Context, cfg *config.Config) (*sql.DB, error) {
+	db, err := repository.OpenDB(cfg.GetDBConnectionString())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.DBTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
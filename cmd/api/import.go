@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"pull_requests_service/internal/config"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/repository"
+	"pull_requests_service/internal/service"
+)
+
+func runImport(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "Input format: json or csv (defaults to the file extension)")
+	dryRun := flags.Bool("dry-run", false, "Validate the file without writing anything")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: api import [-format json|csv] [-dry-run] FILE")
+	}
+	path := flags.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	var input io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	if err := migrateOnStart(cfg); err != nil {
+		return err
+	}
+
+	db, err := openDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	importService := service.NewImportService(
+		repository.NewImportRepository(db, cfg.DBTimeout),
+		repository.NewUserRepository(db, cfg.DBTimeout),
+		repository.NewPRRepository(db, cfg.DBTimeout),
+	)
+
+	report, err := importService.Import(ctx, *format, input, *dryRun)
+	if errors.Is(err, domain.ErrInvalidImport) {
+		for _, importErr := range report.Errors {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", importErr.Location, importErr.Message)
+		}
+		return fmt.Errorf("import rejected: %d invalid rows", len(report.Errors))
+	}
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Import finished", "applied", report.Applied, "teams", report.Teams, "users", report.Users, "pull_requests", report.PullRequests)
+
+	return nil
+}
@@ -2,7 +2,8 @@ package main
 
 import (
 	"context"
-	"log"
+	"flag"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -10,23 +11,51 @@ import (
 	"time"
 
 	"pull_requests_service/internal/config"
+	"pull_requests_service/internal/logging"
 	"pull_requests_service/internal/router"
-	"pull_requests_service/internal/service"
+	"pull_requests_service/internal/tracing"
 )
 
+// shutdownTracing flushes pending spans. It does nothing until tracing is
+// set up, so fatal can call it at any point of startup.
+var shutdownTracing = func(context.Context) error { return nil }
+
 func main() {
 	cfg := config.Load()
 
 	if err := cfg.Validate(); err != nil {
-		log.Fatalf("Invalid configuration: %v", err)
+		fatal("Invalid configuration", err)
 	}
 
-	err := service.RunMigrations(cfg)
+	logger, err := logging.New(os.Stderr, cfg.LogLevel, cfg.LogFormat)
 	if err != nil {
-		log.Fatal("Could not run migrations: %w", err)
+		fatal("Invalid logging configuration", err)
+	}
+	slog.SetDefault(logger)
+
+	shutdown, err := tracing.Setup(context.Background(), cfg.TracingExporter, cfg.TracingSampleRatio)
+	if err != nil {
+		fatal("Could not set up tracing", err)
+	}
+	shutdownTracing = shutdown
+
+	if flag.NArg() > 0 {
+		if err := runCommand(cfg, flag.Args()); err != nil {
+			fatal("Command failed", err)
+		}
+		flushTracing()
+		return
 	}
 
-	handler := router.SetupRouter(cfg)
+	if err := migrateOnStart(cfg); err != nil {
+		fatal("Could not run migrations", err)
+	}
+
+	serving, stopServing := context.WithCancel(context.Background())
+	handler, err := router.SetupRouter(serving, cfg)
+	if err != nil {
+		fatal("Could not set up router", err)
+	}
 	server := &http.Server{
 		Addr:         ":" + cfg.HTTPPort,
 		Handler:      handler,
@@ -35,9 +64,9 @@ func main() {
 	}
 
 	go func() {
-		log.Printf("Server starting on port %s", cfg.HTTPPort)
+		slog.Info("Server starting", "port", cfg.HTTPPort)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed: %v", err)
+			fatal("Server failed", err)
 		}
 	}()
 
@@ -45,14 +74,38 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
+
+	// Fail readiness first and keep serving for a while so load balancers
+	// stop routing new requests before connections are closed.
+	stopServing()
+	time.Sleep(cfg.ShutdownDelay)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		fatal("Server forced to shutdown", err)
 	}
+	flushTracing()
+
+	slog.Info("Server exited")
+}
+
+// flushTracing sends the spans still buffered by the exporter. It must run
+// before the process exits, since os.Exit skips deferred calls.
+func flushTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Could not flush traces", "error", err)
+	}
+}
 
-	log.Println("Server exited")
+// fatal logs the error, flushes traces and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	flushTracing()
+	os.Exit(1)
 }
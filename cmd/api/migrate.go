@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"pull_requests_service/internal/config"
+	"pull_requests_service/internal/service"
+)
+
+const migrateUsage = "usage: api migrate up | down N | goto VERSION | version | force VERSION"
+
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := service.NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	argument := func() (int, error) {
+		if len(args) != 2 {
+			return 0, errors.New(migrateUsage)
+		}
+		value, err := strconv.Atoi(args[1])
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("%s: %q is not a valid number", args[0], args[1])
+		}
+		return value, nil
+	}
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		var steps int
+		if steps, err = argument(); err == nil {
+			err = migrator.Down(steps)
+		}
+	case "goto":
+		var version int
+		if version, err = argument(); err == nil {
+			err = migrator.Goto(uint(version))
+		}
+	case "force":
+		var version int
+		if version, err = argument(); err == nil {
+			err = migrator.Force(version)
+		}
+	case "version":
+	default:
+		return errors.New(migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	slog.Info("Schema version", "version", version, "dirty", dirty)
+
+	return nil
+}
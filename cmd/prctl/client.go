@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func newClient(baseURL, token string, timeout time.Duration) *client {
+	return &client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// apiError is the error envelope returned by the service. Details carries
+// extra context such as the unmet rules of a blocked merge, Fields is only
+// set when request validation failed.
+type apiError struct {
+	Status  int
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details"`
+	Fields  []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"fields"`
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("%s (%d): %s", e.Code, e.Status, e.Message)
+	for _, key := range slices.Sorted(maps.Keys(e.Details)) {
+		msg += fmt.Sprintf("\n  %s: %s", key, e.Details[key])
+	}
+	for _, field := range e.Fields {
+		msg += fmt.Sprintf("\n  %s: %s", field.Field, field.Message)
+	}
+	return msg
+}
+
+func (c *client) get(path string, query url.Values, out any) error {
+	return c.do(http.MethodGet, path, query, nil, out)
+}
+
+func (c *client) post(path string, body, out any) error {
+	return c.do(http.MethodPost, path, nil, body, out)
+}
+
+func (c *client) do(method, path string, query url.Values, body, out any) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, target, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var envelope struct {
+			Error apiError `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil || envelope.Error.Code == "" {
+			return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+		}
+		envelope.Error.Status = resp.StatusCode
+		return &envelope.Error
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
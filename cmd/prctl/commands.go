@@ -0,0 +1,440 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"pull_requests_service/internal/dto"
+)
+
+type app struct {
+	client  *client
+	printer *printer
+}
+
+func (a *app) run(args []string) error {
+	if args[0] == "stats" {
+		return a.stats(args[1:])
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("%s: missing subcommand, see prctl -h", args[0])
+	}
+
+	command, rest := args[0]+" "+args[1], args[2:]
+	switch command {
+	case "team add":
+		return a.teamAdd(rest)
+	case "team get":
+		return a.teamGet(rest)
+	case "team load":
+		return a.teamLoad(rest)
+	case "user activate":
+		return a.userSetActive(rest, true)
+	case "user deactivate":
+		return a.userSetActive(rest, false)
+	case "user reviews":
+		return a.userReviews(rest)
+	case "pr create":
+		return a.prCreate(rest)
+	case "pr get":
+		return a.prGet(rest)
+	case "pr merge":
+		return a.prMerge(rest)
+	case "pr reassign":
+		return a.prReassign(rest)
+	case "pr list":
+		return a.prList(rest)
+	case "token create":
+		return a.tokenCreate(rest)
+	case "token revoke":
+		return a.tokenRevoke(rest)
+	case "token list":
+		return a.tokenList(rest)
+	default:
+		return fmt.Errorf("unknown command %q, see prctl -h", command)
+	}
+}
+
+// positional parses the command flags and requires exactly n positional
+// arguments after them.
+func positional(flags *flag.FlagSet, args []string, n int, usage string) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() != n {
+		return nil, fmt.Errorf("usage: prctl %s", usage)
+	}
+	return flags.Args(), nil
+}
+
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (a *app) teamAdd(args []string) error {
+	flags := flag.NewFlagSet("team add", flag.ContinueOnError)
+	name := flags.String("name", "", "Team name")
+	var members stringList
+	flags.Var(&members, "member", "Member as ID:USERNAME[:SENIORITY[:CAPACITY]], repeatable")
+	if _, err := positional(flags, args, 0, "team add -name NAME -member ID:USERNAME..."); err != nil {
+		return err
+	}
+	if *name == "" {
+		return fmt.Errorf("-name is required")
+	}
+
+	req := dto.AddTeamRequest{TeamName: *name, Members: make([]dto.TeamMember, len(members))}
+	for i, spec := range members {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 || len(parts) > 4 || parts[0] == "" {
+			return fmt.Errorf("invalid member %q, want ID:USERNAME[:SENIORITY[:CAPACITY]]", spec)
+		}
+		member := dto.TeamMember{UserID: parts[0], Username: parts[1], IsActive: true}
+		if len(parts) > 2 {
+			member.Seniority = strings.ToUpper(parts[2])
+		}
+		if len(parts) > 3 {
+			capacity, err := strconv.Atoi(parts[3])
+			if err != nil {
+				return fmt.Errorf("invalid capacity in member %q", spec)
+			}
+			member.ReviewCapacity = capacity
+		}
+		req.Members[i] = member
+	}
+
+	var resp dto.AddTeamResponse
+	if err := a.client.post("/team/add", req, &resp); err != nil {
+		return err
+	}
+	return a.printTeam(resp, resp.Team.TeamName, resp.Team.Members)
+}
+
+func (a *app) teamGet(args []string) error {
+	rest, err := positional(flag.NewFlagSet("team get", flag.ContinueOnError), args, 1, "team get NAME")
+	if err != nil {
+		return err
+	}
+
+	var resp dto.GetTeamResponse
+	if err := a.client.get("/team/get", url.Values{"team_name": {rest[0]}}, &resp); err != nil {
+		return err
+	}
+	return a.printTeam(resp, resp.TeamName, resp.Members)
+}
+
+func (a *app) printTeam(resp any, teamName string, members []dto.TeamMember) error {
+	rows := make([][]string, len(members))
+	for i, member := range members {
+		capacity := "unlimited"
+		if member.ReviewCapacity > 0 {
+			capacity = strconv.Itoa(member.ReviewCapacity)
+		}
+		rows[i] = []string{teamName, member.UserID, member.Username, strconv.FormatBool(member.IsActive),
+			orDash(member.Seniority), capacity}
+	}
+	return a.printer.print(resp, []string{"TEAM", "USER_ID", "USERNAME", "ACTIVE", "SENIORITY", "CAPACITY"}, rows)
+}
+
+func (a *app) teamLoad(args []string) error {
+	rest, err := positional(flag.NewFlagSet("team load", flag.ContinueOnError), args, 1, "team load NAME")
+	if err != nil {
+		return err
+	}
+
+	var resp dto.GetTeamLoadResponse
+	if err := a.client.get("/team/load", url.Values{"team_name": {rest[0]}}, &resp); err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(resp.Members))
+	for i, member := range resp.Members {
+		rows[i] = []string{member.UserID, member.Username, strconv.FormatBool(member.IsActive),
+			strconv.Itoa(member.OpenReviews), strconv.Itoa(member.ReviewsLast7Days), strconv.Itoa(member.ReviewsLast30Days),
+			optionalFloat(member.CapacityUtilization, 2)}
+	}
+	return a.printer.print(resp, []string{"USER_ID", "USERNAME", "ACTIVE", "OPEN", "LAST_7D", "LAST_30D", "UTILIZATION"}, rows)
+}
+
+func (a *app) userSetActive(args []string, isActive bool) error {
+	name := "user deactivate"
+	if isActive {
+		name = "user activate"
+	}
+	rest, err := positional(flag.NewFlagSet(name, flag.ContinueOnError), args, 1, name+" USER_ID")
+	if err != nil {
+		return err
+	}
+
+	var resp dto.SetUserActiveResponse
+	req := dto.SetUserActiveRequest{UserID: rest[0], IsActive: isActive}
+	if err := a.client.post("/users/setIsActive", req, &resp); err != nil {
+		return err
+	}
+
+	user := resp.User
+	return a.printer.print(resp, []string{"USER_ID", "USERNAME", "TEAM", "ACTIVE"},
+		[][]string{{user.UserID, user.Username, user.TeamName, strconv.FormatBool(user.IsActive)}})
+}
+
+func (a *app) userReviews(args []string) error {
+	rest, err := positional(flag.NewFlagSet("user reviews", flag.ContinueOnError), args, 1, "user reviews USER_ID")
+	if err != nil {
+		return err
+	}
+
+	var resp dto.GetUserReviewsResponse
+	if err := a.client.get("/users/getReview", url.Values{"user_id": {rest[0]}}, &resp); err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(resp.PullRequests))
+	for i, pr := range resp.PullRequests {
+		rows[i] = []string{pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status}
+	}
+	return a.printer.print(resp, []string{"ID", "NAME", "AUTHOR", "STATUS"}, rows)
+}
+
+func (a *app) prCreate(args []string) error {
+	flags := flag.NewFlagSet("pr create", flag.ContinueOnError)
+	id := flags.String("id", "", "Pull request ID")
+	name := flags.String("name", "", "Pull request name")
+	author := flags.String("author", "", "Author user ID")
+	parent := flags.String("parent", "", "Parent pull request ID")
+	var coAuthors stringList
+	flags.Var(&coAuthors, "co-author", "Co-author user ID, repeatable")
+	if _, err := positional(flags, args, 0, "pr create -id ID -name NAME -author USER_ID"); err != nil {
+		return err
+	}
+	if *id == "" || *name == "" || *author == "" {
+		return fmt.Errorf("-id, -name and -author are required")
+	}
+
+	req := dto.CreatePRRequest{
+		PullRequestID:       *id,
+		PullRequestName:     *name,
+		AuthorID:            *author,
+		CoAuthors:           coAuthors,
+		ParentPullRequestID: *parent,
+	}
+
+	var resp dto.CreatePRResponse
+	if err := a.client.post("/pullRequest/create", req, &resp); err != nil {
+		return err
+	}
+	return a.printPRs(resp, []dto.PullRequest{resp.PR})
+}
+
+func (a *app) prGet(args []string) error {
+	rest, err := positional(flag.NewFlagSet("pr get", flag.ContinueOnError), args, 1, "pr get ID")
+	if err != nil {
+		return err
+	}
+
+	var resp dto.GetPRResponse
+	if err := a.client.get("/pullRequest/get", url.Values{"pull_request_id": {rest[0]}}, &resp); err != nil {
+		return err
+	}
+
+	rows := [][]string{{"author", resp.Author.UserID, resp.Author.Username, resp.Author.TeamName, "-", "-"}}
+	for _, reviewer := range resp.Reviewers {
+		rows = append(rows, []string{"reviewer", reviewer.UserID, reviewer.Username, reviewer.TeamName,
+			reviewer.ReviewState, optional(reviewer.ReviewedAt)})
+	}
+	if err := a.printPRs(resp, []dto.PullRequest{resp.PR}); err != nil || a.printer.format == formatJSON {
+		return err
+	}
+	fmt.Fprintln(a.printer.w)
+	return a.printer.print(resp, []string{"ROLE", "USER_ID", "USERNAME", "TEAM", "REVIEW", "REVIEWED_AT"}, rows)
+}
+
+func (a *app) prMerge(args []string) error {
+	rest, err := positional(flag.NewFlagSet("pr merge", flag.ContinueOnError), args, 1, "pr merge ID")
+	if err != nil {
+		return err
+	}
+
+	var resp dto.MergePRResponse
+	if err := a.client.post("/pullRequest/merge", dto.MergePRRequest{PullRequestID: rest[0]}, &resp); err != nil {
+		return err
+	}
+	return a.printPRs(resp, []dto.PullRequest{resp.PR})
+}
+
+func (a *app) prReassign(args []string) error {
+	rest, err := positional(flag.NewFlagSet("pr reassign", flag.ContinueOnError), args, 2, "pr reassign ID OLD_REVIEWER_ID")
+	if err != nil {
+		return err
+	}
+
+	var resp dto.ReassignPRResponse
+	req := dto.ReassignPRRequest{PullRequestID: rest[0], OldUserID: rest[1]}
+	if err := a.client.post("/pullRequest/reassign", req, &resp); err != nil {
+		return err
+	}
+	return a.printPRs(resp, []dto.PullRequest{resp.PR})
+}
+
+func (a *app) prList(args []string) error {
+	flags := flag.NewFlagSet("pr list", flag.ContinueOnError)
+	params := map[string]*string{
+		"status":      flags.String("status", "", "OPEN or MERGED"),
+		"author_id":   flags.String("author", "", "Author user ID"),
+		"reviewer_id": flags.String("reviewer", "", "Reviewer user ID"),
+		"team_name":   flags.String("team", "", "Author team"),
+		"q":           flags.String("q", "", "Substring of the PR name"),
+		"sort_by":     flags.String("sort", "", "created_at, updated_at, pull_request_name or pull_request_id"),
+		"order":       flags.String("order", "", "asc or desc"),
+		"limit":       flags.String("limit", "", "Page size"),
+		"cursor":      flags.String("cursor", "", "Cursor of the page to fetch"),
+	}
+	all := flags.Bool("all", false, "Follow cursors and fetch every page")
+	if _, err := positional(flags, args, 0, "pr list [flags]"); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	for name, value := range params {
+		if *value != "" {
+			query.Set(name, *value)
+		}
+	}
+
+	var resp dto.ListPRsResponse
+	if err := a.client.get("/pullRequest/list", query, &resp); err != nil {
+		return err
+	}
+	for *all && resp.NextCursor != "" {
+		var page dto.ListPRsResponse
+		query.Set("cursor", resp.NextCursor)
+		if err := a.client.get("/pullRequest/list", query, &page); err != nil {
+			return err
+		}
+		resp.PullRequests = append(resp.PullRequests, page.PullRequests...)
+		resp.NextCursor = page.NextCursor
+	}
+
+	if err := a.printPRs(resp, resp.PullRequests); err != nil {
+		return err
+	}
+	if resp.NextCursor != "" && a.printer.format == formatTable {
+		fmt.Fprintf(a.printer.w, "\nnext cursor: %s\n", resp.NextCursor)
+	}
+	return nil
+}
+
+func (a *app) printPRs(resp any, prs []dto.PullRequest) error {
+	rows := make([][]string, len(prs))
+	for i, pr := range prs {
+		rows[i] = []string{pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status,
+			orDash(strings.Join(pr.AssignedReviewers, ",")), optional(pr.CreatedAt), optional(pr.MergedAt)}
+	}
+	return a.printer.print(resp, []string{"ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS", "CREATED", "MERGED"}, rows)
+}
+
+func (a *app) stats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	team := flags.String("team", "", "Limit to one team")
+	from := flags.String("from", "", "PRs created at or after (RFC3339)")
+	to := flags.String("to", "", "PRs created before (RFC3339)")
+	if _, err := positional(flags, args, 0, "stats [-team NAME] [-from RFC3339] [-to RFC3339]"); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	for name, value := range map[string]string{"team_name": *team, "from": *from, "to": *to} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+
+	var resp dto.ReviewLatencyResponse
+	if err := a.client.get("/analytics/reviewLatency", query, &resp); err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, team := range resp.Teams {
+		rows = append(rows, latencyRow(team.TeamName, "total", team.Total))
+		for _, week := range team.Weeks {
+			rows = append(rows, latencyRow(team.TeamName, week.WeekStart, week.ReviewLatencyStats))
+		}
+	}
+	return a.printer.print(resp, []string{"TEAM", "WEEK", "CREATED", "MERGED", "REASSIGN_RATE",
+		"ASSIGN_P50_S", "ASSIGN_P90_S", "MERGE_P50_S", "MERGE_P90_S"}, rows)
+}
+
+func latencyRow(teamName, week string, stats dto.ReviewLatencyStats) []string {
+	return []string{teamName, week, strconv.Itoa(stats.PRsCreated), strconv.Itoa(stats.PRsMerged),
+		strconv.FormatFloat(stats.ReassignRate, 'f', 2, 64),
+		optionalFloat(stats.TimeToFirstAssignmentP50Seconds, 0), optionalFloat(stats.TimeToFirstAssignmentP90Seconds, 0),
+		optionalFloat(stats.TimeToMergeP50Seconds, 0), optionalFloat(stats.TimeToMergeP90Seconds, 0)}
+}
+
+func (a *app) tokenCreate(args []string) error {
+	flags := flag.NewFlagSet("token create", flag.ContinueOnError)
+	role := flags.String("role", "", "admin or user")
+	name := flags.String("name", "", "Token description")
+	userID := flags.String("user", "", "User the token belongs to")
+	if _, err := positional(flags, args, 0, "token create -role admin|user [-name NAME] [-user USER_ID]"); err != nil {
+		return err
+	}
+
+	var resp dto.CreateTokenResponse
+	req := dto.CreateTokenRequest{Name: *name, Role: *role, UserID: *userID}
+	if err := a.client.post("/auth/createToken", req, &resp); err != nil {
+		return err
+	}
+	if err := a.printTokens(resp, []dto.APIToken{resp.TokenInfo}); err != nil || a.printer.format == formatJSON {
+		return err
+	}
+	fmt.Fprintf(a.printer.w, "\ntoken (shown only once): %s\n", resp.Token)
+	return nil
+}
+
+func (a *app) tokenRevoke(args []string) error {
+	rest, err := positional(flag.NewFlagSet("token revoke", flag.ContinueOnError), args, 1, "token revoke TOKEN_ID")
+	if err != nil {
+		return err
+	}
+	tokenID, err := strconv.ParseInt(rest[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid token id %q", rest[0])
+	}
+
+	var resp dto.RevokeTokenResponse
+	if err := a.client.post("/auth/revokeToken", dto.RevokeTokenRequest{TokenID: tokenID}, &resp); err != nil {
+		return err
+	}
+	return a.printer.print(resp, []string{"TOKEN_ID", "REVOKED"},
+		[][]string{{strconv.FormatInt(resp.TokenID, 10), strconv.FormatBool(resp.Revoked)}})
+}
+
+func (a *app) tokenList(args []string) error {
+	if _, err := positional(flag.NewFlagSet("token list", flag.ContinueOnError), args, 0, "token list"); err != nil {
+		return err
+	}
+
+	var resp dto.ListTokensResponse
+	if err := a.client.get("/auth/listTokens", nil, &resp); err != nil {
+		return err
+	}
+	return a.printTokens(resp, resp.Tokens)
+}
+
+func (a *app) printTokens(resp any, tokens []dto.APIToken) error {
+	rows := make([][]string, len(tokens))
+	for i, token := range tokens {
+		rows[i] = []string{strconv.FormatInt(token.TokenID, 10), orDash(token.Name), token.Role, orDash(token.UserID),
+			token.CreatedAt, optional(token.RevokedAt)}
+	}
+	return a.printer.print(resp, []string{"TOKEN_ID", "NAME", "ROLE", "USER", "CREATED", "REVOKED"}, rows)
+}
@@ -0,0 +1,69 @@
+// Command prctl operates the pull requests service through its HTTP API.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+const usageText = `Usage: prctl [flags] <command> [arguments]
+
+Commands:
+  team add -name NAME -member ID:USERNAME[:SENIORITY[:CAPACITY]]...
+  team get NAME
+  team load NAME
+  user activate USER_ID
+  user deactivate USER_ID
+  user reviews USER_ID
+  pr create -id ID -name NAME -author USER_ID [-co-author USER_ID]... [-parent ID]
+  pr get ID
+  pr merge ID
+  pr reassign ID OLD_REVIEWER_ID
+  pr list [-status S] [-author ID] [-reviewer ID] [-team NAME] [-q TEXT] [-sort FIELD] [-order asc|desc] [-limit N] [-all]
+  stats [-team NAME] [-from RFC3339] [-to RFC3339]
+  token create -role admin|user [-name NAME] [-user USER_ID]
+  token revoke TOKEN_ID
+  token list
+
+Flags:
+`
+
+func main() {
+	addr := flag.String("addr", getEnv("PRCTL_ADDR", "http://localhost:8080"), "Service base URL")
+	token := flag.String("token", getEnv("PRCTL_TOKEN", ""), "API token sent as a bearer token")
+	output := flag.String("o", formatTable, "Output format: table or json")
+	timeout := flag.Duration("timeout", 10*time.Second, "Request timeout")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usageText)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output != formatTable && *output != formatJSON {
+		fmt.Fprintf(os.Stderr, "prctl: unknown output format %q\n", *output)
+		os.Exit(2)
+	}
+
+	app := &app{
+		client:  newClient(*addr, *token, *timeout),
+		printer: &printer{format: *output, w: os.Stdout},
+	}
+
+	if err := app.run(flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "prctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
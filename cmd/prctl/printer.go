@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+// print writes the raw API response as JSON, or the given rows as an aligned
+// table.
+func (p *printer) print(response any, header []string, rows [][]string) error {
+	if p.format == formatJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(response)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func optional(value *string) string {
+	if value == nil {
+		return "-"
+	}
+	return *value
+}
+
+func optionalFloat(value *float64, precision int) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.*f", precision, *value)
+}
@@ -0,0 +1,65 @@
+// Package auth carries the authenticated caller through request contexts.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"pull_requests_service/internal/domain"
+)
+
+const tokenPrefix = "prs_"
+
+// Identity is the caller behind a request. TokenID is zero for the
+// bootstrap admin token from the configuration.
+type Identity struct {
+	TokenID int64
+	Name    string
+	UserID  string
+	Role    domain.Role
+}
+
+// Actor names the caller in the assignment history: the user id when the
+// caller is bound to a user, the token name otherwise.
+func (i *Identity) Actor() string {
+	if i.UserID != "" {
+		return i.UserID
+	}
+	return "token:" + i.Name
+}
+
+type contextKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(contextKey{}).(*Identity)
+	return identity, ok
+}
+
+// ActorFromContext returns the actor of the caller stored in ctx, or an
+// empty string for calls made outside of an authenticated request.
+func ActorFromContext(ctx context.Context) string {
+	if identity, ok := FromContext(ctx); ok {
+		return identity.Actor()
+	}
+	return ""
+}
+
+// GenerateToken returns a new random bearer token.
+func GenerateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,268 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jwksMinRefresh limits how often a remote key set is refetched, counted from
+// the last attempt whether it succeeded or not.
+const jwksMinRefresh = time.Minute
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type publicKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// KeySet holds the verification keys of a JWKS document loaded from a file
+// or a URL. Remote sets are refreshed every refreshInterval and whenever a
+// token refers to a key id that is not known yet.
+type KeySet struct {
+	file            string
+	url             string
+	refreshInterval time.Duration
+	httpClient      *http.Client
+
+	// refreshMu serializes refetches so concurrent requests wait for one
+	// fetch instead of each starting their own.
+	refreshMu sync.Mutex
+
+	mu          sync.RWMutex
+	keys        map[string]publicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewFileKeySet(path string) (*KeySet, error) {
+	s := &KeySet{file: path}
+	if err := s.load(context.Background()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func NewURLKeySet(url string, refreshInterval time.Duration) (*KeySet, error) {
+	s := &KeySet{
+		url:             url,
+		refreshInterval: refreshInterval,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+	}
+	if err := s.load(context.Background()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *KeySet) key(ctx context.Context, kid string) (publicKey, error) {
+	s.mu.RLock()
+	key, ok := s.lookup(kid)
+	refresh := s.needsRefresh(ok)
+	s.mu.RUnlock()
+
+	if refresh {
+		// Keep using the cached key when the refresh fails.
+		if err := s.refresh(ctx, ok); err != nil && !ok {
+			return publicKey{}, err
+		}
+		s.mu.RLock()
+		key, ok = s.lookup(kid)
+		s.mu.RUnlock()
+	}
+
+	if !ok {
+		return publicKey{}, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+// needsRefresh reports whether a remote set should be refetched because it
+// is older than refreshInterval or the wanted key is missing. The caller
+// holds mu.
+func (s *KeySet) needsRefresh(found bool) bool {
+	if s.url == "" || time.Since(s.attemptedAt) < jwksMinRefresh {
+		return false
+	}
+	return !found || time.Since(s.fetchedAt) > s.refreshInterval
+}
+
+// refresh refetches a remote set unless another caller did while this one
+// was waiting for refreshMu.
+func (s *KeySet) refresh(ctx context.Context, found bool) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	s.mu.Lock()
+	if !s.needsRefresh(found) {
+		s.mu.Unlock()
+		return nil
+	}
+	s.attemptedAt = time.Now()
+	s.mu.Unlock()
+
+	return s.load(ctx)
+}
+
+// lookup finds the key by id. A token without a key id is accepted only
+// when the set holds a single key.
+func (s *KeySet) lookup(kid string) (publicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *KeySet) load(ctx context.Context) error {
+	var data []byte
+	var err error
+	if s.file != "" {
+		data, err = os.ReadFile(s.file)
+	} else {
+		data, err = s.fetch(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("load JWKS: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("parse JWKS: %w", err)
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+func parseJWKS(data []byte) (map[string]publicKey, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]publicKey)
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var key publicKey
+		var err error
+		switch jwk.Kty {
+		case "RSA":
+			key, err = parseRSAKey(jwk)
+		case "EC":
+			key, err = parseECKey(jwk)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+		if jwk.Alg != "" && jwk.Alg != key.alg {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no RS256 or ES256 signing keys")
+	}
+	return keys, nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (publicKey, error) {
+	n, err := decodeBigInt(jwk.N)
+	if err != nil {
+		return publicKey{}, err
+	}
+	e, err := decodeBigInt(jwk.E)
+	if err != nil {
+		return publicKey{}, err
+	}
+	if !e.IsInt64() || e.Int64() < 3 || n.BitLen() < 2048 {
+		return publicKey{}, errors.New("unsupported RSA key parameters")
+	}
+
+	return publicKey{alg: algRS256, key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+}
+
+func parseECKey(jwk jsonWebKey) (publicKey, error) {
+	if jwk.Crv != "P-256" {
+		return publicKey{}, fmt.Errorf("unsupported curve %q", jwk.Crv)
+	}
+	x, err := decodeBigInt(jwk.X)
+	if err != nil {
+		return publicKey{}, err
+	}
+	y, err := decodeBigInt(jwk.Y)
+	if err != nil {
+		return publicKey{}, err
+	}
+
+	key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	if _, err := key.ECDH(); err != nil {
+		return publicKey{}, errors.New("point is not on the curve")
+	}
+
+	return publicKey{alg: algES256, key: key}, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
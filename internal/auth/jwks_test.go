@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaJWK := map[string]string{
+		"kty": "RSA", "kid": "rsa",
+		"n": b64(keys.rsa.N.Bytes()), "e": b64(big.NewInt(int64(keys.rsa.E)).Bytes()),
+	}
+	ecJWK := map[string]string{
+		"kty": "EC", "kid": "ec", "crv": "P-256",
+		"x": b64(keys.ec.X.FillBytes(make([]byte, 32))), "y": b64(keys.ec.Y.FillBytes(make([]byte, 32))),
+	}
+	with := func(jwk map[string]string, overrides map[string]string) map[string]string {
+		result := make(map[string]string, len(jwk))
+		for k, v := range jwk {
+			result[k] = v
+		}
+		for k, v := range overrides {
+			result[k] = v
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		keys     []map[string]string
+		wantKids []string
+		wantErr  bool
+	}{
+		{name: "RSA and EC keys", keys: []map[string]string{rsaJWK, ecJWK}, wantKids: []string{"ec", "rsa"}},
+		{
+			name:     "encryption keys are skipped",
+			keys:     []map[string]string{rsaJWK, with(ecJWK, map[string]string{"use": "enc"})},
+			wantKids: []string{"rsa"},
+		},
+		{
+			name:     "unsupported key types are skipped",
+			keys:     []map[string]string{{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"}, ecJWK},
+			wantKids: []string{"ec"},
+		},
+		{
+			name:     "keys for other algorithms are skipped",
+			keys:     []map[string]string{with(rsaJWK, map[string]string{"alg": "PS256"}), ecJWK},
+			wantKids: []string{"ec"},
+		},
+		{
+			name:    "RSA key shorter than 2048 bits",
+			keys:    []map[string]string{with(rsaJWK, map[string]string{"n": b64(smallRSA.N.Bytes())})},
+			wantErr: true,
+		},
+		{
+			name: "curve other than P-256",
+			keys: []map[string]string{with(ecJWK, map[string]string{
+				"crv": "P-384", "x": b64(p384.X.Bytes()), "y": b64(p384.Y.Bytes()),
+			})},
+			wantErr: true,
+		},
+		{
+			name:    "point not on the curve",
+			keys:    []map[string]string{with(ecJWK, map[string]string{"y": b64(big.NewInt(7).FillBytes(make([]byte, 32)))})},
+			wantErr: true,
+		},
+		{
+			name:    "invalid base64",
+			keys:    []map[string]string{with(rsaJWK, map[string]string{"e": "!!"})},
+			wantErr: true,
+		},
+		{name: "no usable keys", keys: []map[string]string{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := json.Marshal(map[string]any{"keys": tt.keys})
+			parsed, err := parseJWKS(data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseJWKS() = %v, want an error", parsed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseJWKS() error = %v", err)
+			}
+
+			var kids []string
+			for kid := range parsed {
+				kids = append(kids, kid)
+			}
+			slices.Sort(kids)
+			if !slices.Equal(kids, tt.wantKids) {
+				t.Errorf("parseJWKS() kids = %v, want %v", kids, tt.wantKids)
+			}
+		})
+	}
+}
+
+func TestKeySetRefetch(t *testing.T) {
+	keys := newTestKeys(t)
+
+	var fetches atomic.Int32
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if failing.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		// Slow enough for concurrent lookups to pile up behind one fetch.
+		time.Sleep(20 * time.Millisecond)
+		w.Write(keys.jwks())
+	}))
+	defer server.Close()
+
+	set, err := NewURLKeySet(server.URL, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		failing     bool
+		allowRetry  bool
+		stale       bool
+		lookups     int
+		kid         string
+		wantFetches int32
+		wantFound   bool
+	}{
+		{name: "known key is served from the cache", lookups: 10, kid: "rsa", wantFetches: 0, wantFound: true},
+		{name: "concurrent misses share one refetch", allowRetry: true, lookups: 20, kid: "missing", wantFetches: 1},
+		{name: "misses right after a refetch do not refetch", lookups: 5, kid: "missing", wantFetches: 0},
+		{name: "a failed refetch is attempted once", failing: true, allowRetry: true, lookups: 5, kid: "missing", wantFetches: 1},
+		{name: "a failed refetch counts for the rate limit", failing: true, lookups: 5, kid: "missing", wantFetches: 0},
+		{name: "stale keys are kept when the refetch fails", failing: true, allowRetry: true, stale: true, lookups: 1, kid: "ec", wantFetches: 1, wantFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failing.Store(tt.failing)
+			set.mu.Lock()
+			if tt.allowRetry {
+				set.attemptedAt = time.Time{}
+			}
+			if tt.stale {
+				set.fetchedAt = time.Time{}
+			}
+			set.mu.Unlock()
+			before := fetches.Load()
+
+			var wg sync.WaitGroup
+			found := make([]bool, tt.lookups)
+			for i := range tt.lookups {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := set.key(ctx, tt.kid)
+					found[i] = err == nil
+				}()
+			}
+			wg.Wait()
+
+			if got := fetches.Load() - before; got != tt.wantFetches {
+				t.Errorf("fetches = %d, want %d", got, tt.wantFetches)
+			}
+			for i, ok := range found {
+				if ok != tt.wantFound {
+					t.Errorf("lookup %d found = %v, want %v", i, ok, tt.wantFound)
+				}
+			}
+		})
+	}
+}
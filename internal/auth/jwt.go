@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"pull_requests_service/internal/domain"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	algRS256 = "RS256"
+	algES256 = "ES256"
+
+	clockSkew = time.Minute
+)
+
+// JWTVerifier authenticates callers by RS256 or ES256 signed JWTs issued by
+// Issuer for Audience, both of which are required. The user
+// id is read from UserClaim and the caller is an admin when RolesClaim, a
+// list or a space separated string, contains AdminRole.
+type JWTVerifier struct {
+	Keys       *KeySet
+	Issuer     string
+	Audience   string
+	UserClaim  string
+	RolesClaim string
+	AdminRole  string
+}
+
+func (v *JWTVerifier) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	claims, err := v.verify(ctx, token)
+	if err != nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	subject, _ := claims["sub"].(string)
+	userID, _ := claims[v.UserClaim].(string)
+
+	identity := &Identity{Name: subject, UserID: userID, Role: domain.RoleUser}
+	for _, role := range claimStrings(claims[v.RolesClaim]) {
+		if role == v.AdminRole {
+			identity.Role = domain.RoleAdmin
+		}
+	}
+
+	return identity, nil
+}
+
+func (v *JWTVerifier) verify(ctx context.Context, token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, domain.ErrUnauthorized
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	key, err := v.Keys.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if header.Alg != key.alg {
+		return nil, domain.ErrUnauthorized
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if !verifySignature(key, digest[:], signature) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func verifySignature(key publicKey, digest, signature []byte) bool {
+	switch pub := key.key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		// JWS encodes ES256 signatures as the fixed size concatenation r || s.
+		if len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(pub, digest, r, s)
+	}
+	return false
+}
+
+func (v *JWTVerifier) checkClaims(claims map[string]any) error {
+	now := time.Now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return domain.ErrUnauthorized
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return domain.ErrUnauthorized
+	}
+
+	if iss, _ := claims["iss"].(string); iss != v.Issuer {
+		return domain.ErrUnauthorized
+	}
+	if !slices.Contains(claimStrings(claims["aud"]), v.Audience) {
+		return domain.ErrUnauthorized
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// claimStrings reads a claim that may be a single string, a space separated
+// string or a list of strings.
+func claimStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
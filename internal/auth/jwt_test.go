@@ -0,0 +1,278 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"pull_requests_service/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testKeys{rsa: rsaKey, ec: ecKey}
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// jwks renders the public halves of the keys as a JWKS document with the
+// key ids "rsa" and "ec".
+func (k testKeys) jwks() []byte {
+	doc := map[string]any{"keys": []map[string]string{
+		{
+			"kty": "RSA", "kid": "rsa", "alg": algRS256, "use": "sig",
+			"n": b64(k.rsa.N.Bytes()), "e": b64(big.NewInt(int64(k.rsa.E)).Bytes()),
+		},
+		{
+			"kty": "EC", "kid": "ec", "crv": "P-256",
+			"x": b64(k.ec.X.FillBytes(make([]byte, 32))), "y": b64(k.ec.Y.FillBytes(make([]byte, 32))),
+		},
+	}}
+	data, _ := json.Marshal(doc)
+	return data
+}
+
+func (k testKeys) keySet(t *testing.T) *KeySet {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, k.jwks(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := NewFileKeySet(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// signature is how a test token is signed.
+type signature int
+
+const (
+	signRS256 signature = iota
+	signES256
+	signES256DER
+	signNone
+)
+
+func (k testKeys) token(t *testing.T, header, claims map[string]any, sig signature) string {
+	t.Helper()
+	headerJSON, _ := json.Marshal(header)
+	claimsJSON, _ := json.Marshal(claims)
+	signingInput := b64(headerJSON) + "." + b64(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signed []byte
+	var err error
+	switch sig {
+	case signRS256:
+		signed, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+	case signES256:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k.ec, digest[:])
+		if err == nil {
+			signed = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		}
+	case signES256DER:
+		signed, err = ecdsa.SignASN1(rand.Reader, k.ec, digest[:])
+	case signNone:
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + b64(signed)
+}
+
+func TestJWTVerifierAuthenticate(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := &JWTVerifier{
+		Keys:       keys.keySet(t),
+		Issuer:     "https://sso.example.com",
+		Audience:   "pull-requests",
+		UserClaim:  "sub",
+		RolesClaim: "roles",
+		AdminRole:  "admin",
+	}
+
+	now := time.Now()
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss": "https://sso.example.com",
+			"aud": "pull-requests",
+			"sub": "u1",
+			"exp": now.Add(time.Hour).Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+	rs256 := map[string]any{"alg": algRS256, "kid": "rsa"}
+	es256 := map[string]any{"alg": algES256, "kid": "ec"}
+
+	tests := []struct {
+		name     string
+		token    string
+		wantUser string
+		wantRole domain.Role
+	}{
+		{
+			name:     "RS256",
+			token:    keys.token(t, rs256, claims(nil), signRS256),
+			wantUser: "u1",
+			wantRole: domain.RoleUser,
+		},
+		{
+			name:     "ES256 with r||s signature",
+			token:    keys.token(t, es256, claims(nil), signES256),
+			wantUser: "u1",
+			wantRole: domain.RoleUser,
+		},
+		{
+			name:     "admin role from a list",
+			token:    keys.token(t, rs256, claims(map[string]any{"roles": []string{"dev", "admin"}}), signRS256),
+			wantUser: "u1",
+			wantRole: domain.RoleAdmin,
+		},
+		{
+			name:     "admin role from a space separated string",
+			token:    keys.token(t, rs256, claims(map[string]any{"roles": "dev admin"}), signRS256),
+			wantUser: "u1",
+			wantRole: domain.RoleAdmin,
+		},
+		{
+			name:     "audience list",
+			token:    keys.token(t, rs256, claims(map[string]any{"aud": []string{"other", "pull-requests"}}), signRS256),
+			wantUser: "u1",
+			wantRole: domain.RoleUser,
+		},
+		{
+			name:     "expired within the clock skew",
+			token:    keys.token(t, rs256, claims(map[string]any{"exp": now.Add(-30 * time.Second).Unix()}), signRS256),
+			wantUser: "u1",
+			wantRole: domain.RoleUser,
+		},
+		{
+			name:     "not yet valid within the clock skew",
+			token:    keys.token(t, rs256, claims(map[string]any{"nbf": now.Add(30 * time.Second).Unix()}), signRS256),
+			wantUser: "u1",
+			wantRole: domain.RoleUser,
+		},
+		{
+			name:  "expired",
+			token: keys.token(t, rs256, claims(map[string]any{"exp": now.Add(-2 * time.Minute).Unix()}), signRS256),
+		},
+		{
+			name:  "without exp",
+			token: keys.token(t, rs256, claims(map[string]any{"exp": nil}), signRS256),
+		},
+		{
+			name:  "not yet valid",
+			token: keys.token(t, rs256, claims(map[string]any{"nbf": now.Add(2 * time.Minute).Unix()}), signRS256),
+		},
+		{
+			name:  "wrong issuer",
+			token: keys.token(t, rs256, claims(map[string]any{"iss": "https://evil.example.com"}), signRS256),
+		},
+		{
+			name:  "without issuer",
+			token: keys.token(t, rs256, claims(map[string]any{"iss": nil}), signRS256),
+		},
+		{
+			name:  "wrong audience",
+			token: keys.token(t, rs256, claims(map[string]any{"aud": "billing"}), signRS256),
+		},
+		{
+			name:  "without audience",
+			token: keys.token(t, rs256, claims(map[string]any{"aud": nil}), signRS256),
+		},
+		{
+			name:  "alg of another key type",
+			token: keys.token(t, map[string]any{"alg": algES256, "kid": "rsa"}, claims(nil), signRS256),
+		},
+		{
+			name:  "alg none",
+			token: keys.token(t, map[string]any{"alg": "none", "kid": "rsa"}, claims(nil), signNone),
+		},
+		{
+			name:  "HS256",
+			token: keys.token(t, map[string]any{"alg": "HS256", "kid": "rsa"}, claims(nil), signRS256),
+		},
+		{
+			name:  "ES256 with DER signature",
+			token: keys.token(t, es256, claims(nil), signES256DER),
+		},
+		{
+			name:  "signed by another key",
+			token: keys.token(t, map[string]any{"alg": algRS256, "kid": "ec"}, claims(nil), signRS256),
+		},
+		{
+			name:  "unknown key id",
+			token: keys.token(t, map[string]any{"alg": algRS256, "kid": "old"}, claims(nil), signRS256),
+		},
+		{
+			name:  "without key id when the set has several keys",
+			token: keys.token(t, map[string]any{"alg": algRS256}, claims(nil), signRS256),
+		},
+		{
+			name: "tampered claims",
+			token: func() string {
+				parts := strings.Split(keys.token(t, rs256, claims(nil), signRS256), ".")
+				forged, _ := json.Marshal(claims(map[string]any{"roles": "admin"}))
+				return parts[0] + "." + b64(forged) + "." + parts[2]
+			}(),
+		},
+		{name: "not a JWT", token: "prs_abc"},
+		{name: "empty", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Authenticate(context.Background(), tt.token)
+			if tt.wantUser == "" {
+				if !errors.Is(err, domain.ErrUnauthorized) {
+					t.Fatalf("Authenticate() = %+v, %v, want ErrUnauthorized", identity, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if identity.UserID != tt.wantUser || identity.Role != tt.wantRole {
+				t.Errorf("Authenticate() = user %q role %q, want user %q role %q",
+					identity.UserID, identity.Role, tt.wantUser, tt.wantRole)
+			}
+		})
+	}
+}
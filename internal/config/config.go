@@ -10,9 +10,16 @@ import (
 )
 
 type Config struct {
-	HTTPPort     string
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
+	HTTPPort      string
+	ReadTimeout   time.Duration
+	WriteTimeout  time.Duration
+	ShutdownDelay time.Duration
+
+	LogLevel  string
+	LogFormat string
+
+	TracingExporter    string
+	TracingSampleRatio float64
 
 	DBHost     string
 	DBPort     string
@@ -22,6 +29,29 @@ type Config struct {
 	DBSSLMode  string
 	DBMaxConns int
 	DBTimeout  time.Duration
+
+	SkipMigrations bool
+
+	AdminToken string
+
+	AuthMode      string
+	JWKSFile      string
+	JWKSURL       string
+	JWKSRefresh   time.Duration
+	JWTIssuer     string
+	JWTAudience   string
+	JWTUserClaim  string
+	JWTRolesClaim string
+	JWTAdminRole  string
+
+	RateLimits string
+}
+
+// RateLimit is a token bucket refilled with PerMinute tokens a minute and
+// holding at most Burst tokens.
+type RateLimit struct {
+	PerMinute int
+	Burst     int
 }
 
 func Load() *Config {
@@ -30,6 +60,13 @@ func Load() *Config {
 	defaultPort := getEnv("HTTP_PORT", "8080")
 	defaultReadTimeout := getEnv("READ_TIMEOUT", "10s")
 	defaultWriteTimeout := getEnv("WRITE_TIMEOUT", "10s")
+	defaultShutdownDelay := getEnv("SHUTDOWN_DELAY", "5s")
+
+	defaultLogLevel := getEnv("LOG_LEVEL", "info")
+	defaultLogFormat := getEnv("LOG_FORMAT", "json")
+
+	defaultTracingExporter := getEnv("TRACING_EXPORTER", "none")
+	defaultTracingSampleRatio := getEnvFloat("TRACING_SAMPLE_RATIO", 1)
 
 	defaultDBHost := getEnv("DB_HOST", "localhost")
 	defaultDBPort := getEnv("DB_PORT", "5432")
@@ -40,9 +77,32 @@ func Load() *Config {
 	defaultDBMaxConns := getEnvInt("DB_MAX_CONNS", 50)
 	defaultDBTimeout := getEnv("DB_TIMEOUT", "30s")
 
+	defaultSkipMigrations := getEnvBool("SKIP_MIGRATIONS", false)
+
+	defaultAdminToken := getEnv("ADMIN_TOKEN", "")
+
+	defaultAuthMode := getEnv("AUTH_MODE", "token")
+	defaultJWKSFile := getEnv("JWKS_FILE", "")
+	defaultJWKSURL := getEnv("JWKS_URL", "")
+	defaultJWKSRefresh := getEnv("JWKS_REFRESH", "10m")
+	defaultJWTIssuer := getEnv("JWT_ISSUER", "")
+	defaultJWTAudience := getEnv("JWT_AUDIENCE", "")
+	defaultJWTUserClaim := getEnv("JWT_USER_CLAIM", "sub")
+	defaultJWTRolesClaim := getEnv("JWT_ROLES_CLAIM", "roles")
+	defaultJWTAdminRole := getEnv("JWT_ADMIN_ROLE", "admin")
+
+	defaultRateLimits := getEnv("RATE_LIMITS", "read=600:60,write=120:20,admin=60:10,ip=1200:120")
+
 	flag.StringVar(&cfg.HTTPPort, "http-port", defaultPort, "HTTP server port")
 	flag.DurationVar(&cfg.ReadTimeout, "read-timeout", parseDuration(defaultReadTimeout), "Read timeout")
 	flag.DurationVar(&cfg.WriteTimeout, "write-timeout", parseDuration(defaultWriteTimeout), "Write timeout")
+	flag.DurationVar(&cfg.ShutdownDelay, "shutdown-delay", parseDuration(defaultShutdownDelay), "How long to report not ready before shutting down")
+
+	flag.StringVar(&cfg.LogLevel, "log-level", defaultLogLevel, "Log level: debug, info, warn or error")
+	flag.StringVar(&cfg.LogFormat, "log-format", defaultLogFormat, "Log format: json or text")
+
+	flag.StringVar(&cfg.TracingExporter, "tracing-exporter", defaultTracingExporter, "Trace exporter: none, stdout or otlp")
+	flag.Float64Var(&cfg.TracingSampleRatio, "tracing-sample-ratio", defaultTracingSampleRatio, "Share of new traces to sample")
 
 	flag.StringVar(&cfg.DBHost, "db-host", defaultDBHost, "Database host")
 	flag.StringVar(&cfg.DBPort, "db-port", defaultDBPort, "Database port")
@@ -51,7 +111,23 @@ func Load() *Config {
 	flag.StringVar(&cfg.DBPassword, "db-password", defaultDBPassword, "Database password")
 	flag.StringVar(&cfg.DBSSLMode, "db-ssl-mode", defaultDBSSLMode, "Database SSL mode")
 	flag.IntVar(&cfg.DBMaxConns, "db-max-conns", defaultDBMaxConns, "Database max connections")
-	flag.DurationVar(&cfg.DBTimeout, "db-timeout", parseDuration(defaultDBTimeout), "Database connection timeout")
+	flag.DurationVar(&cfg.DBTimeout, "db-timeout", parseDuration(defaultDBTimeout), "Timeout for a single database call")
+
+	flag.BoolVar(&cfg.SkipMigrations, "skip-migrations", defaultSkipMigrations, "Do not apply migrations on startup")
+
+	flag.StringVar(&cfg.AdminToken, "admin-token", defaultAdminToken, "Bootstrap admin API token")
+
+	flag.StringVar(&cfg.AuthMode, "auth-mode", defaultAuthMode, "Authentication mode: token or jwt")
+	flag.StringVar(&cfg.JWKSFile, "jwks-file", defaultJWKSFile, "Path to the JWKS used to verify JWTs")
+	flag.StringVar(&cfg.JWKSURL, "jwks-url", defaultJWKSURL, "URL of the JWKS used to verify JWTs")
+	flag.DurationVar(&cfg.JWKSRefresh, "jwks-refresh", parseDuration(defaultJWKSRefresh), "JWKS URL refresh interval")
+	flag.StringVar(&cfg.JWTIssuer, "jwt-issuer", defaultJWTIssuer, "Required JWT iss claim")
+	flag.StringVar(&cfg.JWTAudience, "jwt-audience", defaultJWTAudience, "Required JWT aud claim")
+	flag.StringVar(&cfg.JWTUserClaim, "jwt-user-claim", defaultJWTUserClaim, "JWT claim holding the user id")
+	flag.StringVar(&cfg.JWTRolesClaim, "jwt-roles-claim", defaultJWTRolesClaim, "JWT claim holding the roles")
+	flag.StringVar(&cfg.JWTAdminRole, "jwt-admin-role", defaultJWTAdminRole, "Role granting admin access")
+
+	flag.StringVar(&cfg.RateLimits, "rate-limits", defaultRateLimits, "Per caller limits as group=per_minute:burst, comma separated; the ip group limits each client IP before authentication")
 
 	flag.Parse()
 
@@ -95,10 +171,78 @@ func (c *Config) Validate() error {
 	if c.DBTimeout <= 0 {
 		return fmt.Errorf("DB timeout must be positive")
 	}
+	if c.ShutdownDelay < 0 {
+		return fmt.Errorf("shutdown delay must not be negative")
+	}
+
+	switch c.AuthMode {
+	case "token":
+	case "jwt":
+		if (c.JWKSFile == "") == (c.JWKSURL == "") {
+			return fmt.Errorf("exactly one of JWKS file and JWKS URL is required in jwt auth mode")
+		}
+		if c.JWKSRefresh <= 0 {
+			return fmt.Errorf("JWKS refresh interval must be positive")
+		}
+		if c.JWTIssuer == "" {
+			return fmt.Errorf("JWT issuer is required in jwt auth mode")
+		}
+		if c.JWTAudience == "" {
+			return fmt.Errorf("JWT audience is required in jwt auth mode")
+		}
+	default:
+		return fmt.Errorf("unknown auth mode %q", c.AuthMode)
+	}
+
+	switch c.TracingExporter {
+	case "none", "stdout", "otlp":
+	default:
+		return fmt.Errorf("unknown tracing exporter %q", c.TracingExporter)
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be between 0 and 1")
+	}
+
+	if _, err := c.GetRateLimits(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// GetRateLimits parses RateLimits into limits per route group. Groups that
+// are not listed are not limited.
+func (c *Config) GetRateLimits() (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit)
+	for _, entry := range strings.Split(c.RateLimits, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		group, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q, expected group=per_minute:burst", entry)
+		}
+		perMinute, burst, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q, expected group=per_minute:burst", entry)
+		}
+
+		limit := RateLimit{}
+		var err error
+		if limit.PerMinute, err = strconv.Atoi(perMinute); err != nil || limit.PerMinute <= 0 {
+			return nil, fmt.Errorf("invalid rate for group %q", group)
+		}
+		if limit.Burst, err = strconv.Atoi(burst); err != nil || limit.Burst <= 0 {
+			return nil, fmt.Errorf("invalid burst for group %q", group)
+		}
+		limits[strings.TrimSpace(group)] = limit
+	}
+
+	return limits, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -115,6 +259,24 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func parseDuration(value string) time.Duration {
 	dur, err := time.ParseDuration(value)
 	if err != nil {
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func validConfig() Config {
+	return Config{
+		HTTPPort:           "8080",
+		ReadTimeout:        10 * time.Second,
+		WriteTimeout:       10 * time.Second,
+		TracingExporter:    "none",
+		TracingSampleRatio: 1,
+		DBHost:             "localhost",
+		DBPort:             "5432",
+		DBName:             "pull_requests",
+		DBUser:             "postgres",
+		DBTimeout:          5 * time.Second,
+		AuthMode:           "token",
+		JWKSRefresh:        time.Hour,
+		RateLimits:         "read=600:60,write=120:20,admin=60:10,ip=1200:120",
+	}
+}
+
+func TestValidateAuthMode(t *testing.T) {
+	jwt := func(mutate func(c *Config)) func(c *Config) {
+		return func(c *Config) {
+			c.AuthMode = "jwt"
+			c.JWKSFile = "/etc/jwks.json"
+			c.JWTIssuer = "https://sso.example.com"
+			c.JWTAudience = "pull-requests"
+			mutate(c)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{name: "token mode", mutate: func(c *Config) {}},
+		{name: "jwt mode with a JWKS file", mutate: jwt(func(c *Config) {})},
+		{name: "jwt mode with a JWKS URL", mutate: jwt(func(c *Config) {
+			c.JWKSFile, c.JWKSURL = "", "https://sso.example.com/jwks"
+		})},
+		{name: "jwt mode without JWKS", mutate: jwt(func(c *Config) { c.JWKSFile = "" }), wantErr: "exactly one of JWKS file and JWKS URL"},
+		{name: "jwt mode with both JWKS sources", mutate: jwt(func(c *Config) {
+			c.JWKSURL = "https://sso.example.com/jwks"
+		}), wantErr: "exactly one of JWKS file and JWKS URL"},
+		{name: "jwt mode without issuer", mutate: jwt(func(c *Config) { c.JWTIssuer = "" }), wantErr: "JWT issuer is required"},
+		{name: "jwt mode without audience", mutate: jwt(func(c *Config) { c.JWTAudience = "" }), wantErr: "JWT audience is required"},
+		{name: "jwt mode without refresh interval", mutate: jwt(func(c *Config) { c.JWKSRefresh = 0 }), wantErr: "JWKS refresh interval"},
+		{name: "unknown mode", mutate: func(c *Config) { c.AuthMode = "basic" }, wantErr: `unknown auth mode "basic"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(&cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(c *Config) {}},
+		{name: "sub-second DB timeout", mutate: func(c *Config) { c.DBTimeout = 250 * time.Millisecond }},
+		{name: "zero DB timeout", mutate: func(c *Config) { c.DBTimeout = 0 }, wantErr: "DB timeout must be positive"},
+		{name: "negative DB timeout", mutate: func(c *Config) { c.DBTimeout = -time.Second }, wantErr: "DB timeout must be positive"},
+		{name: "zero read timeout", mutate: func(c *Config) { c.ReadTimeout = 0 }, wantErr: "read timeout must be positive"},
+		{name: "zero write timeout", mutate: func(c *Config) { c.WriteTimeout = 0 }, wantErr: "write timeout must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(&cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReviewLatencyStats aggregates the PRs of one team opened in one week.
+// WeekStart is nil for the team-wide totals over the whole window.
+// Durations are in seconds and nil when no PR contributed a sample.
+type ReviewLatencyStats struct {
+	TeamName                 string
+	WeekStart                *time.Time
+	PRsCreated               int
+	PRsMerged                int
+	PRsReassigned            int
+	Reassignments            int
+	TimeToFirstAssignmentP50 *float64
+	TimeToFirstAssignmentP90 *float64
+	TimeToMergeP50           *float64
+	TimeToMergeP90           *float64
+}
+
+// ReassignRate is the share of PRs that had at least one reviewer reassigned.
+func (s ReviewLatencyStats) ReassignRate() float64 {
+	if s.PRsCreated == 0 {
+		return 0
+	}
+	return float64(s.PRsReassigned) / float64(s.PRsCreated)
+}
+
+type ReviewLatencyFilter struct {
+	TeamName string
+	From     *time.Time
+	To       *time.Time
+}
+
+type AnalyticsRepository interface {
+	GetReviewLatency(ctx context.Context, filter ReviewLatencyFilter) ([]ReviewLatencyStats, error)
+}
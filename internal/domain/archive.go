@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ArchiveVersion is the layout version written by export. Restore only
+// accepts archives of this version.
+const ArchiveVersion = 2
+
+type TeamMembership struct {
+	TeamName string
+	UserIDs  []string
+}
+
+type RotationCursor struct {
+	TeamName string
+	UserID   string
+}
+
+// Archive is a full snapshot of the service state.
+type Archive struct {
+	Version            int
+	ExportedAt         time.Time
+	Users              []TeamMember
+	Teams              []TeamMembership
+	MergePolicies      []MergePolicy
+	ReviewerExclusions []ReviewerExclusion
+	RotationCursors    []RotationCursor
+	PullRequests       []*PullRequest
+	Reviews            []Review
+	History            []AssignmentRecord
+	ActivityHistory    []ActivityChange
+}
+
+type BackupRepository interface {
+	Export(ctx context.Context) (*Archive, error)
+	// Restore loads the archive in a single transaction and fails with
+	// ErrDatabaseNotEmpty when any user, team or PR already exists.
+	Restore(ctx context.Context, archive *Archive) error
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type AssignmentReason string
+
+const (
+	ReasonRotation       AssignmentReason = "ROTATION"
+	ReasonParentReviewer AssignmentReason = "PARENT_REVIEWER"
+	ReasonSeniorityRule  AssignmentReason = "SENIORITY_RULE"
+	ReasonReassigned     AssignmentReason = "REASSIGNED"
+	ReasonImported       AssignmentReason = "IMPORTED"
+
+	// ReasonPolicySatisfied is the reason of MERGED entries: the merge
+	// passed the team's merge policy.
+	ReasonPolicySatisfied AssignmentReason = "POLICY_SATISFIED"
+
+	ReasonAuthor       AssignmentReason = "AUTHOR"
+	ReasonCoAuthor     AssignmentReason = "CO_AUTHOR"
+	ReasonInactive     AssignmentReason = "INACTIVE"
+	ReasonOverCapacity AssignmentReason = "OVER_CAPACITY"
+	ReasonExcludedPair AssignmentReason = "EXCLUDED_PAIR"
+	ReasonJuniorOnly   AssignmentReason = "JUNIOR_ONLY"
+	ReasonSlotsFilled  AssignmentReason = "SLOTS_FILLED"
+)
+
+// CandidateDecision explains what happened to a single team member during
+// reviewer selection. Position is the place of the member in the rotation
+// order, counted from the team cursor.
+type CandidateDecision struct {
+	UserID   string
+	Position int
+	Chosen   bool
+	Reason   AssignmentReason
+}
+
+type AssignmentPlan struct {
+	TeamName  string
+	Cursor    string
+	Reviewers []string
+	Decisions []CandidateDecision
+}
+
+type AssignmentEvent string
+
+const (
+	EventAssigned   AssignmentEvent = "ASSIGNED"
+	EventUnassigned AssignmentEvent = "UNASSIGNED"
+	// EventReviewed is recorded for the reviewer when a review is submitted;
+	// its reason is the review state.
+	EventReviewed AssignmentEvent = "REVIEWED"
+	// EventMerged is recorded for the PR author when the PR is merged.
+	EventMerged AssignmentEvent = "MERGED"
+)
+
+// AssignmentRecord is a single entry of the PR ledger: reviewer assignments
+// and the reviews and merge that follow them. Actor is the caller that
+// caused the change and is empty for entries recorded before callers were
+// tracked.
+type AssignmentRecord struct {
+	PullRequestID string
+	TeamName      string
+	UserID        string
+	Event         AssignmentEvent
+	Reason        AssignmentReason
+	Actor         string
+	CreatedAt     time.Time
+}
+
+type AssignmentRepository interface {
+	// LockRotation locks the team's rotation until the transaction ctx
+	// carries ends, so concurrent assignments in the team take turns.
+	LockRotation(ctx context.Context, teamName string) error
+	GetRotationCursor(ctx context.Context, teamName string) (string, error)
+	SetRotationCursor(ctx context.Context, teamName, userID string) error
+	RecordAssignments(ctx context.Context, records []AssignmentRecord) error
+	GetAssignmentHistory(ctx context.Context, prID string) ([]AssignmentRecord, error)
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrTeamExists   = errors.New("team already exists")
@@ -9,6 +12,56 @@ var (
 	ErrPRExists     = errors.New("PR already exists")
 	ErrPRNotFound   = errors.New("PR not found")
 	ErrPRMerged     = errors.New("PR is merged")
-	ErrNotAssigned  = errors.New("cannot reassign on merged PR")
+	ErrNotAssigned  = errors.New("reviewer is not assigned to this PR")
 	ErrNoCandidate  = errors.New("no active replacement candidate in team")
+
+	ErrUserInOtherTeam = errors.New("user already belongs to another team")
+
+	ErrMergeBlocked       = errors.New("merge blocked by team policy")
+	ErrInvalidMergePolicy = errors.New("invalid merge policy")
+	ErrInvalidReviewState = errors.New("invalid review state")
+	ErrInvalidSeniority   = errors.New("invalid seniority")
+	ErrInvalidExclusion   = errors.New("user cannot be excluded from reviewing themselves")
+	ErrExclusionNotFound  = errors.New("reviewer exclusion not found")
+	ErrInvalidCoAuthor    = errors.New("author cannot be listed as co-author")
+	ErrInvalidCapacity    = errors.New("review capacity must not be negative")
+	ErrInvalidFilter      = errors.New("invalid list filter")
+	ErrInvalidCursor      = errors.New("invalid page cursor")
+	ErrInvalidImport      = errors.New("import contains invalid rows")
+	ErrImportFormat       = errors.New("unsupported or malformed import file")
+	ErrInvalidArchive     = errors.New("invalid or unsupported archive")
+	ErrDatabaseNotEmpty   = errors.New("restore requires an empty database")
+	ErrUnauthorized       = errors.New("missing or invalid API token")
+	ErrForbidden          = errors.New("token role does not allow this action")
+	ErrInvalidRole        = errors.New("invalid token role")
+	ErrTokenNotFound      = errors.New("API token not found")
 )
+
+// UserInOtherTeamError reports that a user cannot be added to a team because
+// they are a member of another one. It matches ErrUserInOtherTeam.
+type UserInOtherTeamError struct {
+	UserID   string
+	TeamName string
+}
+
+func (e *UserInOtherTeamError) Error() string {
+	return fmt.Sprintf("user %s already belongs to team %s", e.UserID, e.TeamName)
+}
+
+func (e *UserInOtherTeamError) Is(target error) bool {
+	return target == ErrUserInOtherTeam
+}
+
+// MergeBlockedError reports the team policy rules a PR does not satisfy yet.
+// It matches ErrMergeBlocked.
+type MergeBlockedError struct {
+	Violations []PolicyViolation
+}
+
+func (e *MergeBlockedError) Error() string {
+	return fmt.Sprintf("%s: %d unmet rules", ErrMergeBlocked, len(e.Violations))
+}
+
+func (e *MergeBlockedError) Is(target error) bool {
+	return target == ErrMergeBlocked
+}
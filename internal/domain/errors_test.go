@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestUserInOtherTeamError(t *testing.T) {
+	err := fmt.Errorf("add team payments: %w", &UserInOtherTeamError{UserID: "u1", TeamName: "backend"})
+
+	if !errors.Is(err, ErrUserInOtherTeam) {
+		t.Errorf("errors.Is(%v, ErrUserInOtherTeam) = false", err)
+	}
+	if errors.Is(err, ErrTeamExists) || errors.Is(err, ErrMergeBlocked) {
+		t.Errorf("%v matches an unrelated sentinel", err)
+	}
+
+	var target *UserInOtherTeamError
+	if !errors.As(err, &target) {
+		t.Fatalf("errors.As(%v) = false", err)
+	}
+	if target.UserID != "u1" || target.TeamName != "backend" {
+		t.Errorf("target = %+v", target)
+	}
+	if got, want := err.Error(), "add team payments: user u1 already belongs to team backend"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestMergeBlockedError(t *testing.T) {
+	violations := []PolicyViolation{
+		{Rule: PolicyRuleMinApprovals, Message: "1 of 2 required approvals"},
+		{Rule: PolicyRuleSelfApproval, Message: "author cannot approve own PR"},
+	}
+	err := errors.Join(errors.New("audit log unavailable"), &MergeBlockedError{Violations: violations})
+
+	if !errors.Is(err, ErrMergeBlocked) {
+		t.Errorf("errors.Is(%v, ErrMergeBlocked) = false", err)
+	}
+	if errors.Is(err, ErrUserInOtherTeam) {
+		t.Errorf("%v matches ErrUserInOtherTeam", err)
+	}
+
+	var blocked *MergeBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("errors.As(%v) = false", err)
+	}
+	if len(blocked.Violations) != 2 || blocked.Violations[1].Rule != PolicyRuleSelfApproval {
+		t.Errorf("violations = %+v", blocked.Violations)
+	}
+	if got, want := blocked.Error(), "merge blocked by team policy: 2 unmet rules"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+// A bare sentinel carries no context, so it must not pass for a typed error.
+func TestSentinelIsNotTypedError(t *testing.T) {
+	var inOtherTeam *UserInOtherTeamError
+	if errors.As(ErrUserInOtherTeam, &inOtherTeam) {
+		t.Errorf("ErrUserInOtherTeam converted to *UserInOtherTeamError")
+	}
+	var blocked *MergeBlockedError
+	if errors.As(fmt.Errorf("merge: %w", ErrMergeBlocked), &blocked) {
+		t.Errorf("wrapped ErrMergeBlocked converted to *MergeBlockedError")
+	}
+}
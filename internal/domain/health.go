@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ComponentCheck is the outcome of checking one dependency for readiness.
+type ComponentCheck struct {
+	Name    string
+	Healthy bool
+	Message string
+	Latency time.Duration
+	Details map[string]any
+}
+
+// Readiness is ready only when every component is healthy.
+type Readiness struct {
+	Ready      bool
+	Components []ComponentCheck
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	// GetSchemaVersion returns the applied migration version, 0 when no
+	// migration has run yet.
+	GetSchemaVersion(ctx context.Context) (uint, bool, error)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "context"
+
+// ImportBatch is a validated set of teams, members and open PRs that is
+// applied atomically. Assignments records the imported reviewers in the
+// assignment ledger.
+type ImportBatch struct {
+	Teams        []Team
+	PullRequests []*PullRequest
+	Assignments  []AssignmentRecord
+}
+
+// ImportError points at the offending row: "row N" for CSV input or a JSON
+// path such as "teams[0].members[2]".
+type ImportError struct {
+	Location string
+	Message  string
+}
+
+type ImportReport struct {
+	Teams        int
+	Users        int
+	PullRequests int
+	Applied      bool
+	Errors       []ImportError
+}
+
+type ImportRepository interface {
+	ApplyImport(ctx context.Context, batch *ImportBatch) error
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type PolicyRule string
+
+const (
+	PolicyRuleMinApprovals     PolicyRule = "MIN_APPROVALS"
+	PolicyRuleChangesRequested PolicyRule = "NO_CHANGES_REQUESTED"
+	PolicyRuleSeniorApproval   PolicyRule = "SENIOR_APPROVAL"
+	PolicyRuleSelfApproval     PolicyRule = "NO_SELF_APPROVAL"
+)
+
+type MergePolicy struct {
+	TeamName                string
+	MinApprovals            int
+	BlockOnChangesRequested bool
+	RequireSeniorApproval   bool
+	ForbidSelfApproval      bool
+}
+
+type PolicyViolation struct {
+	Rule    PolicyRule
+	Message string
+}
+
+// Evaluate checks the PR reviews against every enabled rule and returns the
+// ones that are not satisfied. An empty result means the PR may be merged.
+// Only reviews of the currently assigned reviewers count, apart from the
+// author's own approval. Members are used to resolve the seniority of the
+// reviewers.
+func (p *MergePolicy) Evaluate(pr *PullRequest, reviews []Review, members []TeamMember) []PolicyViolation {
+	var violations []PolicyViolation
+
+	seniors := make(map[string]bool)
+	for _, member := range members {
+		if member.Seniority == SenioritySenior {
+			seniors[member.UserID] = true
+		}
+	}
+
+	assigned := make(map[string]bool, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		assigned[reviewerID] = true
+	}
+
+	approvals := 0
+	seniorApproved := false
+	selfApproved := false
+	var changesRequestedBy []string
+
+	for _, review := range reviews {
+		if review.UserID == pr.AuthorID {
+			selfApproved = selfApproved || review.State == ReviewStateApproved
+			continue
+		}
+		if !assigned[review.UserID] {
+			continue
+		}
+
+		switch review.State {
+		case ReviewStateApproved:
+			approvals++
+			if seniors[review.UserID] {
+				seniorApproved = true
+			}
+		case ReviewStateChangesRequested:
+			changesRequestedBy = append(changesRequestedBy, review.UserID)
+		}
+	}
+
+	if approvals < p.MinApprovals {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleMinApprovals,
+			Message: fmt.Sprintf("%d of %d required approvals", approvals, p.MinApprovals),
+		})
+	}
+
+	if p.BlockOnChangesRequested && len(changesRequestedBy) > 0 {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleChangesRequested,
+			Message: "changes requested by " + strings.Join(changesRequestedBy, ", "),
+		})
+	}
+
+	if p.RequireSeniorApproval && !seniorApproved {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleSeniorApproval,
+			Message: "no approval from a senior reviewer",
+		})
+	}
+
+	if p.ForbidSelfApproval && selfApproved {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleSelfApproval,
+			Message: "author cannot approve own PR",
+		})
+	}
+
+	return violations
+}
+
+type MergePolicyRepository interface {
+	SaveMergePolicy(ctx context.Context, policy *MergePolicy) error
+	GetMergePolicy(ctx context.Context, teamName string) (*MergePolicy, error)
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergePolicyEvaluate(t *testing.T) {
+	pr := &PullRequest{PullRequestID: "pr-1", AuthorID: "author", AssignedReviewers: []string{"mid", "junior", "senior"}}
+	members := []TeamMember{
+		{UserID: "author", Seniority: SeniorityMiddle},
+		{UserID: "mid", Seniority: SeniorityMiddle},
+		{UserID: "junior", Seniority: SeniorityJunior},
+		{UserID: "senior", Seniority: SenioritySenior},
+		{UserID: "unassigned-senior", Seniority: SenioritySenior},
+	}
+	approved := func(userID string) Review { return Review{UserID: userID, State: ReviewStateApproved} }
+	changes := func(userID string) Review { return Review{UserID: userID, State: ReviewStateChangesRequested} }
+
+	tests := []struct {
+		name    string
+		policy  MergePolicy
+		reviews []Review
+		want    []PolicyRule
+	}{
+		{
+			name:   "default policy allows merging without reviews",
+			policy: MergePolicy{},
+			want:   nil,
+		},
+		{
+			name:    "not enough approvals",
+			policy:  MergePolicy{MinApprovals: 2},
+			reviews: []Review{approved("mid")},
+			want:    []PolicyRule{PolicyRuleMinApprovals},
+		},
+		{
+			name:    "enough approvals",
+			policy:  MergePolicy{MinApprovals: 2},
+			reviews: []Review{approved("mid"), approved("junior")},
+			want:    nil,
+		},
+		{
+			name:    "approvals from unassigned users do not count",
+			policy:  MergePolicy{MinApprovals: 2},
+			reviews: []Review{approved("mid"), approved("unassigned-senior"), approved("made-up")},
+			want:    []PolicyRule{PolicyRuleMinApprovals},
+		},
+		{
+			name:    "self approval does not count",
+			policy:  MergePolicy{MinApprovals: 1},
+			reviews: []Review{approved("author")},
+			want:    []PolicyRule{PolicyRuleMinApprovals},
+		},
+		{
+			name:    "self approval forbidden",
+			policy:  MergePolicy{ForbidSelfApproval: true},
+			reviews: []Review{approved("author"), approved("mid")},
+			want:    []PolicyRule{PolicyRuleSelfApproval},
+		},
+		{
+			name:    "changes requested block when enabled",
+			policy:  MergePolicy{BlockOnChangesRequested: true},
+			reviews: []Review{approved("mid"), changes("junior")},
+			want:    []PolicyRule{PolicyRuleChangesRequested},
+		},
+		{
+			name:    "changes requested ignored when disabled",
+			policy:  MergePolicy{},
+			reviews: []Review{changes("junior")},
+			want:    nil,
+		},
+		{
+			name:    "senior approval missing",
+			policy:  MergePolicy{RequireSeniorApproval: true},
+			reviews: []Review{approved("mid"), changes("senior")},
+			want:    []PolicyRule{PolicyRuleSeniorApproval},
+		},
+		{
+			name:    "senior approval from an unassigned senior",
+			policy:  MergePolicy{RequireSeniorApproval: true},
+			reviews: []Review{approved("unassigned-senior")},
+			want:    []PolicyRule{PolicyRuleSeniorApproval},
+		},
+		{
+			name:    "changes requested by a reviewer no longer assigned",
+			policy:  MergePolicy{BlockOnChangesRequested: true},
+			reviews: []Review{changes("unassigned-senior")},
+			want:    nil,
+		},
+		{
+			name:    "senior approval present",
+			policy:  MergePolicy{RequireSeniorApproval: true},
+			reviews: []Review{approved("senior")},
+			want:    nil,
+		},
+		{
+			name: "every violated rule is reported",
+			policy: MergePolicy{
+				MinApprovals:            1,
+				BlockOnChangesRequested: true,
+				RequireSeniorApproval:   true,
+				ForbidSelfApproval:      true,
+			},
+			reviews: []Review{approved("author"), changes("mid")},
+			want: []PolicyRule{
+				PolicyRuleMinApprovals, PolicyRuleChangesRequested, PolicyRuleSeniorApproval, PolicyRuleSelfApproval,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []PolicyRule
+			for _, violation := range tt.policy.Evaluate(pr, tt.reviews, members) {
+				got = append(got, violation.Rule)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Evaluate() rules = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type PRStatus string
 
@@ -10,18 +13,96 @@ const (
 )
 
 type PullRequest struct {
-	PullRequestID     string
-	PullRequestName   string
-	AuthorID          string
-	Status            PRStatus
-	AssignedReviewers []string
-	MergedAt          *time.Time
+	PullRequestID       string
+	PullRequestName     string
+	AuthorID            string
+	CoAuthors           []string
+	Status              PRStatus
+	AssignedReviewers   []string
+	ParentPullRequestID string
+	MergedAt            *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	// LastAssignedAt is when a reviewer was last assigned to the PR, by
+	// creation or reassignment. It is one timestamp for the whole PR; when each
+	// reviewer was assigned is in the assignment history.
+	LastAssignedAt *time.Time
+}
+
+type PRParticipant struct {
+	User     TeamMember
+	TeamName string
+}
+
+type PRReviewer struct {
+	PRParticipant
+	// Review is nil while the reviewer has not submitted a review yet.
+	Review *Review
+}
+
+type PRDetails struct {
+	PullRequest *PullRequest
+	Author      PRParticipant
+	Reviewers   []PRReviewer
+}
+
+type PRSortField string
+
+const (
+	PRSortCreatedAt PRSortField = "created_at"
+	PRSortUpdatedAt PRSortField = "updated_at"
+	PRSortName      PRSortField = "pull_request_name"
+	PRSortID        PRSortField = "pull_request_id"
+)
+
+func (f PRSortField) IsValid() bool {
+	return f == PRSortCreatedAt || f == PRSortUpdatedAt || f == PRSortName || f == PRSortID
+}
+
+func (f PRSortField) IsTime() bool {
+	return f == PRSortCreatedAt || f == PRSortUpdatedAt
+}
+
+// PRListCursor points at the last PR of the previous page: its value of the
+// sort field and its id as a tie-breaker. It also records the sort field and
+// order it was issued for, since it means nothing for any other.
+type PRListCursor struct {
+	SortBy        PRSortField `json:"s"`
+	Descending    bool        `json:"d,omitempty"`
+	SortValue     string      `json:"v"`
+	PullRequestID string      `json:"id"`
+}
+
+type PRListFilter struct {
+	Status           PRStatus
+	AuthorID         string
+	ReviewerID       string
+	TeamName         string
+	NameContains     string
+	CreatedFrom      *time.Time
+	CreatedTo        *time.Time
+	MergedFrom       *time.Time
+	MergedTo         *time.Time
+	UpdatedFrom      *time.Time
+	UpdatedTo        *time.Time
+	LastAssignedFrom *time.Time
+	LastAssignedTo   *time.Time
+
+	SortBy     PRSortField
+	Descending bool
+	After      *PRListCursor
+	Limit      int
 }
 
 type PRRepository interface {
-	CreatePR(pr *PullRequest) error
-	GetPR(prID string) (*PullRequest, error)
-	UpdatePR(pr *PullRequest) error
-	GetPRsByReviewer(userID string) ([]*PullRequest, error)
-	PRExists(prID string) (bool, error)
+	CreatePR(ctx context.Context, pr *PullRequest) error
+	GetPR(ctx context.Context, prID string) (*PullRequest, error)
+	UpdatePR(ctx context.Context, pr *PullRequest) error
+	// TouchPR moves the PR's updated_at forward to at without rewriting the
+	// rest of the row.
+	TouchPR(ctx context.Context, prID string, at time.Time) error
+	GetPRsByReviewer(ctx context.Context, userID string) ([]*PullRequest, error)
+	PRExists(ctx context.Context, prID string) (bool, error)
+	GetOpenReviewCounts(ctx context.Context, teamName string) (map[string]int, error)
+	ListPRs(ctx context.Context, filter PRListFilter) ([]*PullRequest, error)
 }
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type ReviewState string
+
+const (
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+)
+
+func (s ReviewState) IsValid() bool {
+	return s == ReviewStateApproved || s == ReviewStateChangesRequested
+}
+
+type Review struct {
+	PullRequestID string
+	UserID        string
+	State         ReviewState
+	SubmittedAt   time.Time
+}
+
+type ReviewRepository interface {
+	SaveReview(ctx context.Context, review *Review) error
+	GetReviews(ctx context.Context, prID string) ([]Review, error)
+}
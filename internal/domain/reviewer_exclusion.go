@@ -0,0 +1,42 @@
+package domain
+
+import "context"
+
+// ReviewerExclusion declares that two members of a team must never review
+// each other's pull requests.
+type ReviewerExclusion struct {
+	TeamName string
+	UserID1  string
+	UserID2  string
+}
+
+// NewReviewerExclusion orders the pair so that every exclusion has a single
+// canonical form regardless of the order the users were given in. Ids are
+// compared bytewise, like the COLLATE "C" check on the table.
+func NewReviewerExclusion(teamName, userID, otherUserID string) ReviewerExclusion {
+	if otherUserID < userID {
+		userID, otherUserID = otherUserID, userID
+	}
+	return ReviewerExclusion{
+		TeamName: teamName,
+		UserID1:  userID,
+		UserID2:  otherUserID,
+	}
+}
+
+// Partner returns the other side of the pair if userID is part of it.
+func (e ReviewerExclusion) Partner(userID string) (string, bool) {
+	switch userID {
+	case e.UserID1:
+		return e.UserID2, true
+	case e.UserID2:
+		return e.UserID1, true
+	}
+	return "", false
+}
+
+type ReviewerExclusionRepository interface {
+	AddExclusion(ctx context.Context, exclusion ReviewerExclusion) error
+	RemoveExclusion(ctx context.Context, exclusion ReviewerExclusion) error
+	GetExclusions(ctx context.Context, teamName string) ([]ReviewerExclusion, error)
+}
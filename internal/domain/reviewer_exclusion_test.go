@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestNewReviewerExclusion(t *testing.T) {
+	tests := []struct {
+		name             string
+		userID, otherID  string
+		wantID1, wantID2 string
+	}{
+		{name: "already ordered", userID: "alice", otherID: "bob", wantID1: "alice", wantID2: "bob"},
+		{name: "reversed", userID: "bob", otherID: "alice", wantID1: "alice", wantID2: "bob"},
+		{name: "bytewise, upper case first", userID: "alice", otherID: "Bob", wantID1: "Bob", wantID2: "alice"},
+		{name: "prefix first", userID: "u10", otherID: "u1", wantID1: "u1", wantID2: "u10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewReviewerExclusion("backend", tt.userID, tt.otherID)
+			if got.UserID1 != tt.wantID1 || got.UserID2 != tt.wantID2 {
+				t.Errorf("NewReviewerExclusion(%q, %q) = (%q, %q), want (%q, %q)",
+					tt.userID, tt.otherID, got.UserID1, got.UserID2, tt.wantID1, tt.wantID2)
+			}
+
+			for _, pair := range [][2]string{{tt.userID, tt.otherID}, {tt.otherID, tt.userID}} {
+				if partner, ok := got.Partner(pair[0]); !ok || partner != pair[1] {
+					t.Errorf("Partner(%q) = (%q, %v), want (%q, true)", pair[0], partner, ok, pair[1])
+				}
+			}
+			if _, ok := got.Partner("carol"); ok {
+				t.Errorf("Partner(carol) reported a partner for a user outside the pair")
+			}
+		})
+	}
+}
@@ -1,26 +1,78 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
+type Seniority string
+
+const (
+	SeniorityJunior Seniority = "JUNIOR"
+	SeniorityMiddle Seniority = "MIDDLE"
+	SenioritySenior Seniority = "SENIOR"
+)
+
+func (s Seniority) IsValid() bool {
+	return s == SeniorityJunior || s == SeniorityMiddle || s == SenioritySenior
+}
+
 type Team struct {
 	TeamName string
 	Members  []TeamMember
 }
 
 type TeamMember struct {
-	UserID   string
-	Username string
-	IsActive bool
+	UserID    string
+	Username  string
+	IsActive  bool
+	Seniority Seniority
+	// ReviewCapacity is the maximum number of open PRs the member reviews at
+	// once. Zero means unlimited.
+	ReviewCapacity int
+}
+
+// ActivityChange records that Actor activated or deactivated a user.
+type ActivityChange struct {
+	UserID    string
+	TeamName  string
+	IsActive  bool
+	Actor     string
+	CreatedAt time.Time
+}
+
+// MemberLoad is a member's review workload. Recent counts are the ASSIGNED
+// events in the assignment history since the given point in time, so
+// reviews the member was later unassigned from and merged PRs still count.
+type MemberLoad struct {
+	TeamMember
+	OpenReviews       int
+	ReviewsLast7Days  int
+	ReviewsLast30Days int
+}
+
+// CapacityUtilization is the share of review capacity taken by open reviews,
+// or nil when the member's capacity is unlimited.
+func (l MemberLoad) CapacityUtilization() *float64 {
+	if l.ReviewCapacity <= 0 {
+		return nil
+	}
+	utilization := float64(l.OpenReviews) / float64(l.ReviewCapacity)
+	return &utilization
 }
 
 type TeamRepository interface {
-	CreateTeam(team *Team) error
-	GetTeam(teamName string) (*Team, error)
-	TeamExists(teamName string) (bool, error)
+	CreateTeam(ctx context.Context, team *Team) error
+	GetTeam(ctx context.Context, teamName string) (*Team, error)
+	TeamExists(ctx context.Context, teamName string) (bool, error)
+	GetTeamLoad(ctx context.Context, teamName string, weekAgo, monthAgo time.Time) ([]MemberLoad, error)
 }
 
 type UserRepository interface {
-	CreateOrUpdateUser(user *TeamMember) error
-	GetUserTeam(userID string) (string, error)
-	SetUserActive(userID string, isActive bool) error
-	GetUser(userID string) (*TeamMember, error)
-	GetActiveTeamMembers(teamName string) ([]TeamMember, error)
+	CreateOrUpdateUser(ctx context.Context, user *TeamMember) error
+	GetUserTeam(ctx context.Context, userID string) (string, error)
+	SetUserActive(ctx context.Context, userID string, isActive bool) error
+	RecordActivityChange(ctx context.Context, change ActivityChange) error
+	GetUser(ctx context.Context, userID string) (*TeamMember, error)
+	GetActiveTeamMembers(ctx context.Context, teamName string) ([]TeamMember, error)
 }
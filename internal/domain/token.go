@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleUser  Role = "user"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleAdmin || r == RoleUser
+}
+
+// Allows reports whether a caller with this role may use an endpoint that
+// requires the given role. Admins may use everything.
+func (r Role) Allows(required Role) bool {
+	return r == RoleAdmin || r == required
+}
+
+// APIToken describes an issued bearer token. Only the SHA-256 hash of the
+// token is stored; the token itself is shown once when it is created.
+type APIToken struct {
+	TokenID   int64
+	Name      string
+	Role      Role
+	UserID    string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+type TokenRepository interface {
+	CreateToken(ctx context.Context, token *APIToken, tokenHash string) error
+	GetTokenByHash(ctx context.Context, tokenHash string) (*APIToken, error)
+	RevokeToken(ctx context.Context, tokenID int64) error
+	ListTokens(ctx context.Context) ([]APIToken, error)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "testing"
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{RoleAdmin, RoleAdmin, true},
+		{RoleAdmin, RoleUser, true},
+		{RoleUser, RoleUser, true},
+		{RoleUser, RoleAdmin, false},
+		{"", RoleUser, false},
+		{"owner", RoleUser, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Allows(tt.required); got != tt.want {
+			t.Errorf("Role(%q).Allows(%q) = %v, want %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+}
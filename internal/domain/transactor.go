@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// Transactor runs a unit of work in a single database transaction.
+// Repository calls made with the context passed to fn take part in it; the
+// transaction commits when fn returns nil and rolls back otherwise.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
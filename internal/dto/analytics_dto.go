@@ -0,0 +1,34 @@
+package dto
+
+type ReviewLatencyRequest struct {
+	TeamName string
+	From     string
+	To       string
+}
+
+type ReviewLatencyStats struct {
+	PRsCreated                      int      `json:"prs_created"`
+	PRsMerged                       int      `json:"prs_merged"`
+	PRsReassigned                   int      `json:"prs_reassigned"`
+	Reassignments                   int      `json:"reassignments"`
+	ReassignRate                    float64  `json:"reassign_rate"`
+	TimeToFirstAssignmentP50Seconds *float64 `json:"time_to_first_assignment_p50_seconds"`
+	TimeToFirstAssignmentP90Seconds *float64 `json:"time_to_first_assignment_p90_seconds"`
+	TimeToMergeP50Seconds           *float64 `json:"time_to_merge_p50_seconds"`
+	TimeToMergeP90Seconds           *float64 `json:"time_to_merge_p90_seconds"`
+}
+
+type WeeklyReviewLatency struct {
+	WeekStart string `json:"week_start"`
+	ReviewLatencyStats
+}
+
+type TeamReviewLatency struct {
+	TeamName string                `json:"team_name"`
+	Total    ReviewLatencyStats    `json:"total"`
+	Weeks    []WeeklyReviewLatency `json:"weeks"`
+}
+
+type ReviewLatencyResponse struct {
+	Teams []TeamReviewLatency `json:"teams"`
+}
@@ -0,0 +1,89 @@
+package dto
+
+import "time"
+
+type ArchiveUser struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	IsActive       bool   `json:"is_active"`
+	Seniority      string `json:"seniority"`
+	ReviewCapacity int    `json:"review_capacity"`
+}
+
+type ArchiveTeam struct {
+	TeamName string   `json:"team_name"`
+	Members  []string `json:"members"`
+}
+
+type ArchiveReviewerExclusion struct {
+	TeamName    string `json:"team_name"`
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+}
+
+type ArchiveRotationCursor struct {
+	TeamName   string `json:"team_name"`
+	LastUserID string `json:"last_user_id"`
+}
+
+type ArchivePullRequest struct {
+	PullRequestID       string     `json:"pull_request_id"`
+	PullRequestName     string     `json:"pull_request_name"`
+	AuthorID            string     `json:"author_id"`
+	CoAuthors           []string   `json:"co_authors,omitempty"`
+	Status              string     `json:"status"`
+	AssignedReviewers   []string   `json:"assigned_reviewers"`
+	ParentPullRequestID string     `json:"parent_pull_request_id,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	LastAssignedAt      *time.Time `json:"last_assigned_at,omitempty"`
+	MergedAt            *time.Time `json:"merged_at,omitempty"`
+}
+
+type ArchiveReview struct {
+	PullRequestID string    `json:"pull_request_id"`
+	UserID        string    `json:"user_id"`
+	State         string    `json:"state"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+}
+
+type ArchiveHistoryRecord struct {
+	PullRequestID string    `json:"pull_request_id"`
+	TeamName      string    `json:"team_name"`
+	UserID        string    `json:"user_id"`
+	Event         string    `json:"event"`
+	Reason        string    `json:"reason"`
+	Actor         string    `json:"actor,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ArchiveActivityChange struct {
+	UserID    string    `json:"user_id"`
+	TeamName  string    `json:"team_name"`
+	IsActive  bool      `json:"is_active"`
+	Actor     string    `json:"actor,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Archive struct {
+	Version            int                        `json:"version"`
+	ExportedAt         time.Time                  `json:"exported_at"`
+	Users              []ArchiveUser              `json:"users"`
+	Teams              []ArchiveTeam              `json:"teams"`
+	MergePolicies      []MergePolicy              `json:"merge_policies"`
+	ReviewerExclusions []ArchiveReviewerExclusion `json:"reviewer_exclusions"`
+	RotationCursors    []ArchiveRotationCursor    `json:"rotation_cursors"`
+	PullRequests       []ArchivePullRequest       `json:"pull_requests"`
+	Reviews            []ArchiveReview            `json:"reviews"`
+	History            []ArchiveHistoryRecord     `json:"history"`
+	ActivityHistory    []ArchiveActivityChange    `json:"activity_history"`
+}
+
+type RestoreResponse struct {
+	Users           int `json:"users"`
+	Teams           int `json:"teams"`
+	PullRequests    int `json:"pull_requests"`
+	Reviews         int `json:"reviews"`
+	History         int `json:"history"`
+	ActivityHistory int `json:"activity_history"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+type CandidateDecision struct {
+	UserID   string `json:"user_id"`
+	Position int    `json:"position"`
+	Chosen   bool   `json:"chosen"`
+	Reason   string `json:"reason"`
+}
+
+type PreviewAssignmentResponse struct {
+	TeamName  string              `json:"team_name"`
+	Cursor    string              `json:"cursor"`
+	Reviewers []CandidateDecision `json:"reviewers"`
+	Excluded  []CandidateDecision `json:"excluded"`
+}
+
+type AssignmentRecord struct {
+	UserID    string `json:"user_id"`
+	TeamName  string `json:"team_name"`
+	Event     string `json:"event"`
+	Reason    string `json:"reason"`
+	Actor     string `json:"actor,omitempty"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type GetAssignmentHistoryResponse struct {
+	PullRequestID string             `json:"pull_request_id"`
+	History       []AssignmentRecord `json:"history"`
+}
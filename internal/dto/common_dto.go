@@ -1,9 +1,17 @@
 package dto
 
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 type ErrorResponse struct {
 	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
+		Code    string            `json:"code"`
+		Message string            `json:"message"`
+		Fields  []FieldError      `json:"fields,omitempty"`
+		Details map[string]string `json:"details,omitempty"`
 	} `json:"error"`
 }
 
@@ -13,3 +21,9 @@ func NewErrorResponse(code, message string) ErrorResponse {
 	resp.Error.Message = message
 	return resp
 }
+
+func NewValidationErrorResponse(fields []FieldError) ErrorResponse {
+	resp := NewErrorResponse("VALIDATION_FAILED", "request validation failed")
+	resp.Error.Fields = fields
+	return resp
+}
@@ -0,0 +1,17 @@
+package dto
+
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+type ComponentStatus struct {
+	Status    string         `json:"status"`
+	Message   string         `json:"message,omitempty"`
+	LatencyMs *float64       `json:"latency_ms,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+type ImportMember struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	IsActive       *bool  `json:"is_active,omitempty"`
+	Seniority      string `json:"seniority,omitempty"`
+	ReviewCapacity int    `json:"review_capacity,omitempty"`
+}
+
+type ImportTeam struct {
+	TeamName string         `json:"team_name"`
+	Members  []ImportMember `json:"members"`
+}
+
+type ImportPullRequest struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+}
+
+type ImportRequest struct {
+	Teams        []ImportTeam        `json:"teams"`
+	PullRequests []ImportPullRequest `json:"pull_requests"`
+}
+
+type ImportError struct {
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+type ImportResponse struct {
+	Applied      bool          `json:"applied"`
+	Teams        int           `json:"teams"`
+	Users        int           `json:"users"`
+	PullRequests int           `json:"pull_requests"`
+	Errors       []ImportError `json:"errors"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type MergePolicy struct {
+	TeamName                string `json:"team_name"`
+	MinApprovals            int    `json:"min_approvals"`
+	BlockOnChangesRequested bool   `json:"block_on_changes_requested"`
+	RequireSeniorApproval   bool   `json:"require_senior_approval"`
+	ForbidSelfApproval      bool   `json:"forbid_self_approval"`
+}
+
+type SetMergePolicyRequest struct {
+	TeamName                string `json:"team_name"`
+	MinApprovals            int    `json:"min_approvals"`
+	BlockOnChangesRequested bool   `json:"block_on_changes_requested"`
+	RequireSeniorApproval   bool   `json:"require_senior_approval"`
+	ForbidSelfApproval      bool   `json:"forbid_self_approval"`
+}
+
+type SetMergePolicyResponse struct {
+	Policy MergePolicy `json:"policy"`
+}
+
+type GetMergePolicyResponse struct {
+	Policy MergePolicy `json:"policy"`
+}
@@ -1,12 +1,17 @@
 package dto
 
 type PullRequest struct {
-	PullRequestID     string   `json:"pull_request_id"`
-	PullRequestName   string   `json:"pull_request_name"`
-	AuthorID          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
-	MergedAt          *string  `json:"mergedAt,omitempty"`
+	PullRequestID       string   `json:"pull_request_id"`
+	PullRequestName     string   `json:"pull_request_name"`
+	AuthorID            string   `json:"author_id"`
+	CoAuthors           []string `json:"co_authors,omitempty"`
+	Status              string   `json:"status"`
+	AssignedReviewers   []string `json:"assigned_reviewers"`
+	ParentPullRequestID string   `json:"parent_pull_request_id,omitempty"`
+	CreatedAt           *string  `json:"createdAt,omitempty"`
+	UpdatedAt           *string  `json:"updatedAt,omitempty"`
+	LastAssignedAt      *string  `json:"lastAssignedAt,omitempty"`
+	MergedAt            *string  `json:"mergedAt,omitempty"`
 }
 
 type PullRequestShort struct {
@@ -17,9 +22,11 @@ type PullRequestShort struct {
 }
 
 type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID       string   `json:"pull_request_id"`
+	PullRequestName     string   `json:"pull_request_name"`
+	AuthorID            string   `json:"author_id"`
+	CoAuthors           []string `json:"co_authors,omitempty"`
+	ParentPullRequestID string   `json:"parent_pull_request_id,omitempty"`
 }
 
 type CreatePRResponse struct {
@@ -43,3 +50,57 @@ type ReassignPRResponse struct {
 	PR         PullRequest `json:"pr"`
 	ReplacedBy string      `json:"replaced_by"`
 }
+
+type Review struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	State         string `json:"state"`
+	SubmittedAt   string `json:"submittedAt"`
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	State         string `json:"state"`
+}
+
+type SubmitReviewResponse struct {
+	Review Review `json:"review"`
+}
+
+type ListPRsRequest struct {
+	Status           string
+	AuthorID         string
+	ReviewerID       string
+	TeamName         string
+	Query            string
+	CreatedFrom      string
+	CreatedTo        string
+	MergedFrom       string
+	MergedTo         string
+	UpdatedFrom      string
+	UpdatedTo        string
+	LastAssignedFrom string
+	LastAssignedTo   string
+	SortBy           string
+	Order            string
+	Limit            string
+	Cursor           string
+}
+
+type ListPRsResponse struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
+type PRReviewer struct {
+	User
+	ReviewState string  `json:"review_state"`
+	ReviewedAt  *string `json:"reviewedAt,omitempty"`
+}
+
+type GetPRResponse struct {
+	PR        PullRequest  `json:"pr"`
+	Author    User         `json:"author"`
+	Reviewers []PRReviewer `json:"reviewers"`
+}
@@ -1,9 +1,11 @@
 package dto
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	IsActive       bool   `json:"is_active"`
+	Seniority      string `json:"seniority,omitempty"`
+	ReviewCapacity int    `json:"review_capacity,omitempty"`
 }
 
 type Team struct {
@@ -24,3 +26,37 @@ type GetTeamResponse struct {
 	TeamName string       `json:"team_name"`
 	Members  []TeamMember `json:"members"`
 }
+
+type ReviewerExclusion struct {
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+}
+
+type ReviewerExclusionRequest struct {
+	TeamName    string `json:"team_name"`
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+}
+
+type ReviewerExclusionResponse struct {
+	TeamName  string            `json:"team_name"`
+	Exclusion ReviewerExclusion `json:"exclusion"`
+}
+
+type GetReviewerExclusionsResponse struct {
+	TeamName   string              `json:"team_name"`
+	Exclusions []ReviewerExclusion `json:"exclusions"`
+}
+
+type MemberLoad struct {
+	TeamMember
+	OpenReviews         int      `json:"open_reviews"`
+	ReviewsLast7Days    int      `json:"reviews_last_7_days"`
+	ReviewsLast30Days   int      `json:"reviews_last_30_days"`
+	CapacityUtilization *float64 `json:"capacity_utilization"`
+}
+
+type GetTeamLoadResponse struct {
+	TeamName string       `json:"team_name"`
+	Members  []MemberLoad `json:"members"`
+}
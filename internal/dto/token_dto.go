@@ -0,0 +1,34 @@
+package dto
+
+type APIToken struct {
+	TokenID   int64   `json:"token_id"`
+	Name      string  `json:"name"`
+	Role      string  `json:"role"`
+	UserID    string  `json:"user_id,omitempty"`
+	CreatedAt string  `json:"createdAt"`
+	RevokedAt *string `json:"revokedAt,omitempty"`
+}
+
+type CreateTokenRequest struct {
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	UserID string `json:"user_id,omitempty"`
+}
+
+type CreateTokenResponse struct {
+	Token     string   `json:"token"`
+	TokenInfo APIToken `json:"token_info"`
+}
+
+type RevokeTokenRequest struct {
+	TokenID int64 `json:"token_id"`
+}
+
+type RevokeTokenResponse struct {
+	TokenID int64 `json:"token_id"`
+	Revoked bool  `json:"revoked"`
+}
+
+type ListTokensResponse struct {
+	Tokens []APIToken `json:"tokens"`
+}
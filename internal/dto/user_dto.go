@@ -1,10 +1,11 @@
 package dto
 
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	TeamName  string `json:"team_name"`
+	IsActive  bool   `json:"is_active"`
+	Seniority string `json:"seniority"`
 }
 
 type SetUserActiveRequest struct {
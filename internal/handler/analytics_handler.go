@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/service"
+	"strconv"
+)
+
+const weekStartLayout = "2006-01-02"
+
+type AnalyticsHandler struct {
+	analyticsService *service.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: analyticsService}
+}
+
+func (h *AnalyticsHandler) GetReviewLatency(w http.ResponseWriter, r *http.Request) {
+	stats, ok := h.reviewLatency(w, r)
+	if !ok {
+		return
+	}
+
+	response := dto.ReviewLatencyResponse{Teams: []dto.TeamReviewLatency{}}
+	for _, s := range stats {
+		if s.WeekStart == nil {
+			response.Teams = append(response.Teams, dto.TeamReviewLatency{
+				TeamName: s.TeamName,
+				Total:    latencyStatsToDTO(s),
+				Weeks:    []dto.WeeklyReviewLatency{},
+			})
+			continue
+		}
+
+		// Totals sort first within each team, so the week belongs to the last entry.
+		team := &response.Teams[len(response.Teams)-1]
+		team.Weeks = append(team.Weeks, dto.WeeklyReviewLatency{
+			WeekStart:          s.WeekStart.Format(weekStartLayout),
+			ReviewLatencyStats: latencyStatsToDTO(s),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *AnalyticsHandler) GetReviewLatencyCSV(w http.ResponseWriter, r *http.Request) {
+	stats, ok := h.reviewLatency(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="review_latency.csv"`)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"team_name", "week_start", "prs_created", "prs_merged", "prs_reassigned", "reassignments", "reassign_rate",
+		"time_to_first_assignment_p50_seconds", "time_to_first_assignment_p90_seconds",
+		"time_to_merge_p50_seconds", "time_to_merge_p90_seconds",
+	})
+	for _, s := range stats {
+		weekStart := "total"
+		if s.WeekStart != nil {
+			weekStart = s.WeekStart.Format(weekStartLayout)
+		}
+		writer.Write([]string{
+			s.TeamName,
+			weekStart,
+			strconv.Itoa(s.PRsCreated),
+			strconv.Itoa(s.PRsMerged),
+			strconv.Itoa(s.PRsReassigned),
+			strconv.Itoa(s.Reassignments),
+			strconv.FormatFloat(s.ReassignRate(), 'f', 4, 64),
+			formatSeconds(s.TimeToFirstAssignmentP50),
+			formatSeconds(s.TimeToFirstAssignmentP90),
+			formatSeconds(s.TimeToMergeP50),
+			formatSeconds(s.TimeToMergeP90),
+		})
+	}
+	writer.Flush()
+}
+
+func (h *AnalyticsHandler) reviewLatency(w http.ResponseWriter, r *http.Request) ([]domain.ReviewLatencyStats, bool) {
+	query := r.URL.Query()
+	req := dto.ReviewLatencyRequest{
+		TeamName: query.Get("team_name"),
+		From:     query.Get("from"),
+		To:       query.Get("to"),
+	}
+
+	var v validator
+	v.optionalName("team_name", req.TeamName)
+	if !v.check(w) {
+		return nil, false
+	}
+
+	stats, err := h.analyticsService.GetReviewLatency(r.Context(), req)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return nil, false
+	}
+
+	return stats, true
+}
+
+func latencyStatsToDTO(s domain.ReviewLatencyStats) dto.ReviewLatencyStats {
+	return dto.ReviewLatencyStats{
+		PRsCreated:                      s.PRsCreated,
+		PRsMerged:                       s.PRsMerged,
+		PRsReassigned:                   s.PRsReassigned,
+		Reassignments:                   s.Reassignments,
+		ReassignRate:                    s.ReassignRate(),
+		TimeToFirstAssignmentP50Seconds: s.TimeToFirstAssignmentP50,
+		TimeToFirstAssignmentP90Seconds: s.TimeToFirstAssignmentP90,
+		TimeToMergeP50Seconds:           s.TimeToMergeP50,
+		TimeToMergeP90Seconds:           s.TimeToMergeP90,
+	}
+}
+
+func formatSeconds(seconds *float64) string {
+	if seconds == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*seconds, 'f', 0, 64)
+}
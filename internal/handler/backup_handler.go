@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/service"
+)
+
+type BackupHandler struct {
+	backupService *service.BackupService
+}
+
+func NewBackupHandler(backupService *service.BackupService) *BackupHandler {
+	return &BackupHandler{backupService: backupService}
+}
+
+func (h *BackupHandler) Export(w http.ResponseWriter, r *http.Request) {
+	archive, err := h.backupService.Export(r.Context())
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("pull_requests_service-%s.json", archive.ExportedAt.Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	json.NewEncoder(w).Encode(ArchiveToDTO(archive))
+}
+
+func (h *BackupHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	var req dto.Archive
+	if !decodeJSONLimit(w, r, &req, maxImportSize) {
+		return
+	}
+
+	archive := ArchiveFromDTO(&req)
+	if err := h.backupService.Restore(r.Context(), archive); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.RestoreResponse{
+		Users:           len(archive.Users),
+		Teams:           len(archive.Teams),
+		PullRequests:    len(archive.PullRequests),
+		Reviews:         len(archive.Reviews),
+		History:         len(archive.History),
+		ActivityHistory: len(archive.ActivityHistory),
+	})
+}
+
+// ArchiveToDTO converts an archive to its JSON form. The export command of
+// cmd/api uses it too, so both produce the same file.
+func ArchiveToDTO(archive *domain.Archive) *dto.Archive {
+	result := &dto.Archive{
+		Version:            archive.Version,
+		ExportedAt:         archive.ExportedAt,
+		Users:              make([]dto.ArchiveUser, len(archive.Users)),
+		Teams:              make([]dto.ArchiveTeam, len(archive.Teams)),
+		MergePolicies:      make([]dto.MergePolicy, len(archive.MergePolicies)),
+		ReviewerExclusions: make([]dto.ArchiveReviewerExclusion, len(archive.ReviewerExclusions)),
+		RotationCursors:    make([]dto.ArchiveRotationCursor, len(archive.RotationCursors)),
+		PullRequests:       make([]dto.ArchivePullRequest, len(archive.PullRequests)),
+		Reviews:            make([]dto.ArchiveReview, len(archive.Reviews)),
+		History:            make([]dto.ArchiveHistoryRecord, len(archive.History)),
+		ActivityHistory:    make([]dto.ArchiveActivityChange, len(archive.ActivityHistory)),
+	}
+
+	for i, user := range archive.Users {
+		result.Users[i] = dto.ArchiveUser{
+			UserID:         user.UserID,
+			Username:       user.Username,
+			IsActive:       user.IsActive,
+			Seniority:      string(user.Seniority),
+			ReviewCapacity: user.ReviewCapacity,
+		}
+	}
+	for i, team := range archive.Teams {
+		result.Teams[i] = dto.ArchiveTeam{TeamName: team.TeamName, Members: team.UserIDs}
+	}
+	for i, policy := range archive.MergePolicies {
+		result.MergePolicies[i] = dto.MergePolicy{
+			TeamName:                policy.TeamName,
+			MinApprovals:            policy.MinApprovals,
+			BlockOnChangesRequested: policy.BlockOnChangesRequested,
+			RequireSeniorApproval:   policy.RequireSeniorApproval,
+			ForbidSelfApproval:      policy.ForbidSelfApproval,
+		}
+	}
+	for i, exclusion := range archive.ReviewerExclusions {
+		result.ReviewerExclusions[i] = dto.ArchiveReviewerExclusion{
+			TeamName:    exclusion.TeamName,
+			UserID:      exclusion.UserID1,
+			OtherUserID: exclusion.UserID2,
+		}
+	}
+	for i, cursor := range archive.RotationCursors {
+		result.RotationCursors[i] = dto.ArchiveRotationCursor{TeamName: cursor.TeamName, LastUserID: cursor.UserID}
+	}
+	for i, pr := range archive.PullRequests {
+		result.PullRequests[i] = dto.ArchivePullRequest{
+			PullRequestID:       pr.PullRequestID,
+			PullRequestName:     pr.PullRequestName,
+			AuthorID:            pr.AuthorID,
+			CoAuthors:           pr.CoAuthors,
+			Status:              string(pr.Status),
+			AssignedReviewers:   pr.AssignedReviewers,
+			ParentPullRequestID: pr.ParentPullRequestID,
+			CreatedAt:           pr.CreatedAt,
+			UpdatedAt:           pr.UpdatedAt,
+			LastAssignedAt:      pr.LastAssignedAt,
+			MergedAt:            pr.MergedAt,
+		}
+	}
+	for i, review := range archive.Reviews {
+		result.Reviews[i] = dto.ArchiveReview{
+			PullRequestID: review.PullRequestID,
+			UserID:        review.UserID,
+			State:         string(review.State),
+			SubmittedAt:   review.SubmittedAt,
+		}
+	}
+	for i, record := range archive.History {
+		result.History[i] = dto.ArchiveHistoryRecord{
+			PullRequestID: record.PullRequestID,
+			TeamName:      record.TeamName,
+			UserID:        record.UserID,
+			Event:         string(record.Event),
+			Reason:        string(record.Reason),
+			Actor:         record.Actor,
+			CreatedAt:     record.CreatedAt,
+		}
+	}
+	for i, change := range archive.ActivityHistory {
+		result.ActivityHistory[i] = dto.ArchiveActivityChange{
+			UserID:    change.UserID,
+			TeamName:  change.TeamName,
+			IsActive:  change.IsActive,
+			Actor:     change.Actor,
+			CreatedAt: change.CreatedAt,
+		}
+	}
+
+	return result
+}
+
+// ArchiveFromDTO is the inverse of ArchiveToDTO.
+func ArchiveFromDTO(archive *dto.Archive) *domain.Archive {
+	result := &domain.Archive{
+		Version:            archive.Version,
+		ExportedAt:         archive.ExportedAt,
+		Users:              make([]domain.TeamMember, len(archive.Users)),
+		Teams:              make([]domain.TeamMembership, len(archive.Teams)),
+		MergePolicies:      make([]domain.MergePolicy, len(archive.MergePolicies)),
+		ReviewerExclusions: make([]domain.ReviewerExclusion, len(archive.ReviewerExclusions)),
+		RotationCursors:    make([]domain.RotationCursor, len(archive.RotationCursors)),
+		PullRequests:       make([]*domain.PullRequest, len(archive.PullRequests)),
+		Reviews:            make([]domain.Review, len(archive.Reviews)),
+		History:            make([]domain.AssignmentRecord, len(archive.History)),
+		ActivityHistory:    make([]domain.ActivityChange, len(archive.ActivityHistory)),
+	}
+
+	for i, user := range archive.Users {
+		result.Users[i] = domain.TeamMember{
+			UserID:         user.UserID,
+			Username:       user.Username,
+			IsActive:       user.IsActive,
+			Seniority:      domain.Seniority(user.Seniority),
+			ReviewCapacity: user.ReviewCapacity,
+		}
+	}
+	for i, team := range archive.Teams {
+		result.Teams[i] = domain.TeamMembership{TeamName: team.TeamName, UserIDs: team.Members}
+	}
+	for i, policy := range archive.MergePolicies {
+		result.MergePolicies[i] = domain.MergePolicy{
+			TeamName:                policy.TeamName,
+			MinApprovals:            policy.MinApprovals,
+			BlockOnChangesRequested: policy.BlockOnChangesRequested,
+			RequireSeniorApproval:   policy.RequireSeniorApproval,
+			ForbidSelfApproval:      policy.ForbidSelfApproval,
+		}
+	}
+	for i, exclusion := range archive.ReviewerExclusions {
+		result.ReviewerExclusions[i] = domain.ReviewerExclusion{
+			TeamName: exclusion.TeamName,
+			UserID1:  exclusion.UserID,
+			UserID2:  exclusion.OtherUserID,
+		}
+	}
+	for i, cursor := range archive.RotationCursors {
+		result.RotationCursors[i] = domain.RotationCursor{TeamName: cursor.TeamName, UserID: cursor.LastUserID}
+	}
+	for i, pr := range archive.PullRequests {
+		result.PullRequests[i] = &domain.PullRequest{
+			PullRequestID:       pr.PullRequestID,
+			PullRequestName:     pr.PullRequestName,
+			AuthorID:            pr.AuthorID,
+			CoAuthors:           pr.CoAuthors,
+			Status:              domain.PRStatus(pr.Status),
+			AssignedReviewers:   pr.AssignedReviewers,
+			ParentPullRequestID: pr.ParentPullRequestID,
+			CreatedAt:           pr.CreatedAt,
+			UpdatedAt:           pr.UpdatedAt,
+			LastAssignedAt:      pr.LastAssignedAt,
+			MergedAt:            pr.MergedAt,
+		}
+	}
+	for i, review := range archive.Reviews {
+		result.Reviews[i] = domain.Review{
+			PullRequestID: review.PullRequestID,
+			UserID:        review.UserID,
+			State:         domain.ReviewState(review.State),
+			SubmittedAt:   review.SubmittedAt,
+		}
+	}
+	for i, record := range archive.History {
+		result.History[i] = domain.AssignmentRecord{
+			PullRequestID: record.PullRequestID,
+			TeamName:      record.TeamName,
+			UserID:        record.UserID,
+			Event:         domain.AssignmentEvent(record.Event),
+			Reason:        domain.AssignmentReason(record.Reason),
+			Actor:         record.Actor,
+			CreatedAt:     record.CreatedAt,
+		}
+	}
+	for i, change := range archive.ActivityHistory {
+		result.ActivityHistory[i] = domain.ActivityChange{
+			UserID:    change.UserID,
+			TeamName:  change.TeamName,
+			IsActive:  change.IsActive,
+			Actor:     change.Actor,
+			CreatedAt: change.CreatedAt,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"pull_requests_service/internal/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArchiveDTORoundTrip(t *testing.T) {
+	now := time.Date(2025, 11, 3, 10, 0, 0, 0, time.UTC)
+	assigned := now.Add(time.Minute)
+	merged := now.Add(time.Hour)
+
+	archive := &domain.Archive{
+		Version:    domain.ArchiveVersion,
+		ExportedAt: now,
+		Users: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, Seniority: domain.SenioritySenior, ReviewCapacity: 3},
+			{UserID: "u2", Username: "Bob", Seniority: domain.SeniorityJunior},
+		},
+		Teams: []domain.TeamMembership{{TeamName: "backend", UserIDs: []string{"u1", "u2"}}},
+		MergePolicies: []domain.MergePolicy{{
+			TeamName: "backend", MinApprovals: 2, BlockOnChangesRequested: true,
+			RequireSeniorApproval: true, ForbidSelfApproval: true,
+		}},
+		ReviewerExclusions: []domain.ReviewerExclusion{domain.NewReviewerExclusion("backend", "u2", "u1")},
+		RotationCursors:    []domain.RotationCursor{{TeamName: "backend", UserID: "u1"}},
+		PullRequests: []*domain.PullRequest{{
+			PullRequestID: "pr-1", PullRequestName: "Fix", AuthorID: "u2", CoAuthors: []string{"u1"},
+			Status: domain.PRStatusMerged, AssignedReviewers: []string{"u1"}, ParentPullRequestID: "pr-0",
+			CreatedAt: now, UpdatedAt: merged, LastAssignedAt: &assigned, MergedAt: &merged,
+		}},
+		Reviews: []domain.Review{{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateApproved, SubmittedAt: now}},
+		History: []domain.AssignmentRecord{{
+			PullRequestID: "pr-1", TeamName: "backend", UserID: "u1", Event: domain.EventAssigned,
+			Reason: domain.ReasonRotation, Actor: "token:ci", CreatedAt: assigned,
+		}},
+		ActivityHistory: []domain.ActivityChange{{
+			UserID: "u2", TeamName: "backend", IsActive: false, Actor: "u1", CreatedAt: merged,
+		}},
+	}
+
+	got := ArchiveFromDTO(ArchiveToDTO(archive))
+	if !reflect.DeepEqual(got, archive) {
+		t.Errorf("ArchiveFromDTO(ArchiveToDTO(a)) = %+v, want %+v", got, archive)
+	}
+}
@@ -7,7 +7,11 @@ import (
 )
 
 func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, dto.NewErrorResponse(code, message))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(dto.NewErrorResponse(code, message))
+	json.NewEncoder(w).Encode(body)
 }
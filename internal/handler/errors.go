@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+)
+
+// errorMapping is how a domain error is rendered to the client.
+type errorMapping struct {
+	err     error
+	status  int
+	code    string
+	message string
+}
+
+// errorMappings maps domain errors to HTTP responses. Every handler goes
+// through it, so the same error always gets the same status, code and message
+// whichever endpoint returned it.
+var errorMappings = []errorMapping{
+	{domain.ErrTeamNotFound, http.StatusNotFound, "TEAM_NOT_FOUND", "team not found"},
+	{domain.ErrUserNotFound, http.StatusNotFound, "USER_NOT_FOUND", "user not found"},
+	{domain.ErrPRNotFound, http.StatusNotFound, "PR_NOT_FOUND", "PR not found"},
+	{domain.ErrExclusionNotFound, http.StatusNotFound, "EXCLUSION_NOT_FOUND", "reviewer exclusion not found"},
+	{domain.ErrTokenNotFound, http.StatusNotFound, "TOKEN_NOT_FOUND", "token not found or already revoked"},
+
+	{domain.ErrTeamExists, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists"},
+	{domain.ErrPRExists, http.StatusConflict, "PR_EXISTS", "PR id already exists"},
+	{domain.ErrPRMerged, http.StatusConflict, "PR_MERGED", "PR is already merged"},
+	{domain.ErrNotAssigned, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR"},
+	{domain.ErrNoCandidate, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team"},
+	{domain.ErrUserInOtherTeam, http.StatusConflict, "USER_IN_OTHER_TEAM", "user already belongs to another team"},
+	{domain.ErrMergeBlocked, http.StatusConflict, "MERGE_BLOCKED", "merge blocked by team policy"},
+	{domain.ErrDatabaseNotEmpty, http.StatusConflict, "DATABASE_NOT_EMPTY", "restore requires an empty database"},
+
+	{domain.ErrInvalidMergePolicy, http.StatusBadRequest, "INVALID_POLICY", "min_approvals must not be negative"},
+	{domain.ErrInvalidReviewState, http.StatusBadRequest, "INVALID_REVIEW_STATE", "state must be APPROVED or CHANGES_REQUESTED"},
+	{domain.ErrInvalidSeniority, http.StatusBadRequest, "INVALID_SENIORITY", "seniority must be JUNIOR, MIDDLE or SENIOR"},
+	{domain.ErrInvalidExclusion, http.StatusBadRequest, "INVALID_EXCLUSION", "user_id and other_user_id must differ"},
+	{domain.ErrInvalidCoAuthor, http.StatusBadRequest, "INVALID_CO_AUTHOR", "author cannot be listed as co-author"},
+	{domain.ErrInvalidCapacity, http.StatusBadRequest, "INVALID_CAPACITY", "review_capacity must not be negative"},
+	{domain.ErrInvalidFilter, http.StatusBadRequest, "INVALID_FILTER", "invalid filter, sorting, limit or time range"},
+	{domain.ErrInvalidCursor, http.StatusBadRequest, "INVALID_CURSOR", "invalid cursor"},
+	{domain.ErrImportFormat, http.StatusBadRequest, "INVALID_FORMAT", "format must be json or csv"},
+	{domain.ErrInvalidImport, http.StatusBadRequest, "INVALID_IMPORT", "import contains invalid rows"},
+	{domain.ErrInvalidArchive, http.StatusBadRequest, "INVALID_ARCHIVE", "archive version is unsupported or references missing records"},
+	{domain.ErrInvalidRole, http.StatusBadRequest, "INVALID_ROLE", "role must be admin or user"},
+
+	{domain.ErrUnauthorized, http.StatusUnauthorized, "UNAUTHORIZED", "missing or invalid API token"},
+	{domain.ErrForbidden, http.StatusForbidden, "FORBIDDEN", "token role does not allow this action"},
+
+	{context.DeadlineExceeded, http.StatusServiceUnavailable, "TIMEOUT", "the request timed out, try again later"},
+}
+
+// writeDomainError renders err using errorMappings. Typed errors that carry
+// details are rendered with them in the response's details first, wherever
+// they are in the chain.
+// Errors without a mapping are logged and answered with a generic 500 so
+// internals never leak to the client.
+func writeDomainError(w http.ResponseWriter, r *http.Request, err error) {
+	var blocked *domain.MergeBlockedError
+	if errors.As(err, &blocked) {
+		resp := dto.NewErrorResponse("MERGE_BLOCKED", domain.ErrMergeBlocked.Error())
+		resp.Error.Details = make(map[string]string, len(blocked.Violations))
+		for _, violation := range blocked.Violations {
+			resp.Error.Details[string(violation.Rule)] = violation.Message
+		}
+		writeJSON(w, http.StatusConflict, resp)
+		return
+	}
+
+	var inOtherTeam *domain.UserInOtherTeamError
+	if errors.As(err, &inOtherTeam) {
+		resp := dto.NewErrorResponse("USER_IN_OTHER_TEAM", inOtherTeam.Error())
+		resp.Error.Details = map[string]string{
+			"user_id":   inOtherTeam.UserID,
+			"team_name": inOtherTeam.TeamName,
+		}
+		writeJSON(w, http.StatusConflict, resp)
+		return
+	}
+
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.err) {
+			writeError(w, mapping.status, mapping.code, mapping.message)
+			return
+		}
+	}
+
+	slog.ErrorContext(r.Context(), "Request failed", "error", err)
+	writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"reflect"
+	"testing"
+)
+
+func TestErrorMappingsAreDistinct(t *testing.T) {
+	seen := make(map[error]bool, len(errorMappings))
+	codes := make(map[string]error, len(errorMappings))
+	for _, mapping := range errorMappings {
+		if seen[mapping.err] {
+			t.Errorf("%v is mapped more than once", mapping.err)
+		}
+		seen[mapping.err] = true
+
+		if other, ok := codes[mapping.code]; ok {
+			t.Errorf("%v and %v share the code %s", other, mapping.err, mapping.code)
+		}
+		codes[mapping.code] = mapping.err
+
+		if mapping.status < 400 || mapping.code == "" || mapping.message == "" {
+			t.Errorf("%v has an incomplete mapping: %+v", mapping.err, mapping)
+		}
+	}
+}
+
+func TestWriteDomainError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantDetails map[string]string
+	}{
+		{name: "missing team", err: domain.ErrTeamNotFound, wantStatus: http.StatusNotFound, wantCode: "TEAM_NOT_FOUND"},
+		{name: "missing user", err: domain.ErrUserNotFound, wantStatus: http.StatusNotFound, wantCode: "USER_NOT_FOUND"},
+		{name: "missing PR", err: domain.ErrPRNotFound, wantStatus: http.StatusNotFound, wantCode: "PR_NOT_FOUND"},
+		{
+			name:       "missing exclusion",
+			err:        domain.ErrExclusionNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "EXCLUSION_NOT_FOUND",
+		},
+		{
+			name:       "wrapped missing token",
+			err:        fmt.Errorf("revoke token: %w", domain.ErrTokenNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "TOKEN_NOT_FOUND",
+		},
+		{
+			name:       "wrapped sentinel error",
+			err:        fmt.Errorf("get pr: %w", domain.ErrPRMerged),
+			wantStatus: http.StatusConflict,
+			wantCode:   "PR_MERGED",
+		},
+		{
+			name:       "auth error",
+			err:        domain.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantCode:   "FORBIDDEN",
+		},
+		{
+			name:       "timeout",
+			err:        fmt.Errorf("list prs: %w", context.DeadlineExceeded),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "TIMEOUT",
+		},
+		{
+			name:       "user in other team carries details",
+			err:        fmt.Errorf("add team: %w", &domain.UserInOtherTeamError{UserID: "u1", TeamName: "backend"}),
+			wantStatus: http.StatusConflict,
+			wantCode:   "USER_IN_OTHER_TEAM",
+			wantDetails: map[string]string{
+				"user_id":   "u1",
+				"team_name": "backend",
+			},
+		},
+		{
+			name:       "unknown error does not leak",
+			err:        errors.New("pq: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			writeDomainError(w, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var resp dto.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Error.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", resp.Error.Code, tt.wantCode)
+			}
+			if !reflect.DeepEqual(resp.Error.Details, tt.wantDetails) {
+				t.Errorf("details = %v, want %v", resp.Error.Details, tt.wantDetails)
+			}
+			if tt.wantStatus == http.StatusInternalServerError && resp.Error.Message != "internal server error" {
+				t.Errorf("message = %q leaks the cause", resp.Error.Message)
+			}
+		})
+	}
+}
+
+func TestWriteDomainErrorMergeBlocked(t *testing.T) {
+	err := fmt.Errorf("merge: %w", &domain.MergeBlockedError{Violations: []domain.PolicyViolation{
+		{Rule: domain.PolicyRuleMinApprovals, Message: "needs 2 approvals, has 1"},
+		{Rule: domain.PolicyRuleSeniorApproval, Message: "needs a senior approval"},
+	}})
+
+	w := httptest.NewRecorder()
+	writeDomainError(w, httptest.NewRequest(http.MethodPost, "/pullRequest/merge", nil), err)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	var resp dto.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Error.Code != "MERGE_BLOCKED" {
+		t.Errorf("code = %q, want MERGE_BLOCKED", resp.Error.Code)
+	}
+	want := map[string]string{
+		"MIN_APPROVALS":   "needs 2 approvals, has 1",
+		"SENIOR_APPROVAL": "needs a senior approval",
+	}
+	if !reflect.DeepEqual(resp.Error.Details, want) {
+		t.Errorf("details = %v, want %v", resp.Error.Details, want)
+	}
+}
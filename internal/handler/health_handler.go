@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/service"
+)
+
+type HealthHandler struct {
+	healthService *service.HealthService
+}
+
+func NewHealthHandler(healthService *service.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// Livez reports that the process is up and serving requests. It does not
+// look at dependencies, so a database outage does not get the pod restarted.
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.LivenessResponse{Status: "alive"})
+}
+
+// Readyz reports whether the service can take traffic: the database answers,
+// the schema is at the version this binary expects and shutdown has not
+// begun. It answers 503 otherwise.
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	readiness := h.healthService.CheckReadiness(r.Context())
+
+	response := dto.ReadinessResponse{
+		Status:     "ready",
+		Components: make(map[string]dto.ComponentStatus, len(readiness.Components)),
+	}
+	status := http.StatusOK
+	if !readiness.Ready {
+		response.Status = "not_ready"
+		status = http.StatusServiceUnavailable
+	}
+
+	for _, component := range readiness.Components {
+		componentStatus := dto.ComponentStatus{
+			Status:  "up",
+			Message: component.Message,
+			Details: component.Details,
+		}
+		if !component.Healthy {
+			componentStatus.Status = "down"
+		}
+		if component.Latency > 0 {
+			latency := float64(component.Latency.Microseconds()) / 1000
+			componentStatus.LatencyMs = &latency
+		}
+		response.Components[component.Name] = componentStatus
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/service"
+	"strings"
+	"testing"
+)
+
+type fakeHealthRepo struct {
+	pingErr error
+	version uint
+}
+
+func (f fakeHealthRepo) Ping(ctx context.Context) error { return f.pingErr }
+
+func (f fakeHealthRepo) GetSchemaVersion(ctx context.Context) (uint, bool, error) {
+	return f.version, false, nil
+}
+
+func TestReadyz(t *testing.T) {
+	unreachable := errors.New(`pq: password authentication failed for user "pr_service"`)
+
+	tests := []struct {
+		name       string
+		repo       fakeHealthRepo
+		wantStatus int
+		wantBody   string
+		wantDown   []string
+	}{
+		{name: "ready", repo: fakeHealthRepo{version: 7}, wantStatus: http.StatusOK, wantBody: "ready"},
+		{name: "database down", repo: fakeHealthRepo{pingErr: unreachable, version: 7}, wantStatus: http.StatusServiceUnavailable, wantBody: "not_ready", wantDown: []string{"database"}},
+		{name: "schema mismatch", repo: fakeHealthRepo{version: 8}, wantStatus: http.StatusServiceUnavailable, wantBody: "not_ready", wantDown: []string{"migrations"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(service.NewHealthService(tt.repo, 7, context.Background()))
+
+			w := httptest.NewRecorder()
+			h.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Cache-Control"); got != "no-store" {
+				t.Errorf("Cache-Control = %q", got)
+			}
+			if strings.Contains(w.Body.String(), "pq:") {
+				t.Errorf("body leaks the driver error: %s", w.Body.String())
+			}
+
+			var resp dto.ReadinessResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != tt.wantBody {
+				t.Errorf("status = %q, want %q", resp.Status, tt.wantBody)
+			}
+			for name, component := range resp.Components {
+				want := "up"
+				for _, down := range tt.wantDown {
+					if name == down {
+						want = "down"
+					}
+				}
+				if component.Status != want {
+					t.Errorf("%s = %q, want %q", name, component.Status, want)
+				}
+			}
+			if db := resp.Components["database"]; db.LatencyMs == nil {
+				t.Errorf("database latency missing")
+			}
+		})
+	}
+}
+
+func TestLivezIgnoresDependencies(t *testing.T) {
+	h := NewHealthHandler(service.NewHealthService(fakeHealthRepo{pingErr: errors.New("down")}, 7, context.Background()))
+
+	w := httptest.NewRecorder()
+	h.Livez(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"alive"`) {
+		t.Errorf("Livez() = %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/service"
+	"strconv"
+	"strings"
+)
+
+const maxImportSize = 32 << 20
+
+type ImportHandler struct {
+	importService *service.ImportService
+}
+
+func NewImportHandler(importService *service.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = service.ImportFormatJSON
+		if strings.Contains(r.Header.Get("Content-Type"), "csv") {
+			format = service.ImportFormatCSV
+		}
+	}
+
+	dryRun := false
+	if value := query.Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			var v validator
+			v.add("dry_run", "INVALID_FORMAT", "dry_run must be true or false")
+			v.check(w)
+			return
+		}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	report, err := h.importService.Import(r.Context(), format, body, dryRun)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidImport) {
+			writeJSON(w, http.StatusBadRequest, importReportToDTO(report))
+			return
+		}
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(importReportToDTO(report))
+}
+
+func importReportToDTO(report *domain.ImportReport) dto.ImportResponse {
+	response := dto.ImportResponse{
+		Applied:      report.Applied,
+		Teams:        report.Teams,
+		Users:        report.Users,
+		PullRequests: report.PullRequests,
+		Errors:       make([]dto.ImportError, len(report.Errors)),
+	}
+
+	for i, importErr := range report.Errors {
+		response.Errors[i] = dto.ImportError{
+			Location: importErr.Location,
+			Message:  importErr.Message,
+		}
+	}
+
+	return response
+}
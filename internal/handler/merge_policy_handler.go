@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/service"
+)
+
+type MergePolicyHandler struct {
+	policyService *service.MergePolicyService
+}
+
+func NewMergePolicyHandler(policyService *service.MergePolicyService) *MergePolicyHandler {
+	return &MergePolicyHandler{policyService: policyService}
+}
+
+func (h *MergePolicyHandler) SetMergePolicy(w http.ResponseWriter, r *http.Request) {
+	var req dto.SetMergePolicyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	v.name("team_name", req.TeamName)
+	if !v.check(w) {
+		return
+	}
+
+	policy, err := h.policyService.SetMergePolicy(r.Context(), req)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.SetMergePolicyResponse{Policy: domainPolicyToDTO(policy)})
+}
+
+func (h *MergePolicyHandler) GetMergePolicy(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	var v validator
+	v.name("team_name", teamName)
+	if !v.check(w) {
+		return
+	}
+
+	policy, err := h.policyService.GetMergePolicy(r.Context(), teamName)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.GetMergePolicyResponse{Policy: domainPolicyToDTO(policy)})
+}
+
+func domainPolicyToDTO(policy *domain.MergePolicy) dto.MergePolicy {
+	return dto.MergePolicy{
+		TeamName:                policy.TeamName,
+		MinApprovals:            policy.MinApprovals,
+		BlockOnChangesRequested: policy.BlockOnChangesRequested,
+		RequireSeniorApproval:   policy.RequireSeniorApproval,
+		ForbidSelfApproval:      policy.ForbidSelfApproval,
+	}
+}
@@ -19,21 +19,19 @@ func NewPRHandler(prService *service.PRService) *PRHandler {
 
 func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreatePRRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "NOT_FOUND", "invalid request body")
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	validateCreatePR(&v, req)
+	if !v.check(w) {
 		return
 	}
 
 	pr, err := h.prService.CreatePR(r.Context(), req)
 	if err != nil {
-		switch err {
-		case domain.ErrPRExists:
-			writeError(w, http.StatusConflict, "PR_EXISTS", "PR id already exists")
-		case domain.ErrUserNotFound:
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
-		default:
-			writeError(w, http.StatusInternalServerError, "NOT_FOUND", err.Error())
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
@@ -43,21 +41,57 @@ func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(dto.CreatePRResponse{PR: response})
 }
 
+func (h *PRHandler) GetPR(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	var v validator
+	v.id("pull_request_id", prID)
+	if !v.check(w) {
+		return
+	}
+
+	details, err := h.prService.GetPRDetails(r.Context(), prID)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.GetPRResponse{
+		PR:        h.domainPRToDTO(details.PullRequest),
+		Author:    participantToDTO(details.Author),
+		Reviewers: make([]dto.PRReviewer, len(details.Reviewers)),
+	}
+
+	for i, reviewer := range details.Reviewers {
+		response.Reviewers[i] = dto.PRReviewer{
+			User:        participantToDTO(reviewer.PRParticipant),
+			ReviewState: "PENDING",
+		}
+		if reviewer.Review != nil {
+			reviewedAt := reviewer.Review.SubmittedAt.Format(time.RFC3339)
+			response.Reviewers[i].ReviewState = string(reviewer.Review.State)
+			response.Reviewers[i].ReviewedAt = &reviewedAt
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 	var req dto.MergePRRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "NOT_FOUND", "invalid request body")
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	v.id("pull_request_id", req.PullRequestID)
+	if !v.check(w) {
 		return
 	}
 
 	pr, err := h.prService.MergePR(r.Context(), req.PullRequestID)
 	if err != nil {
-		switch err {
-		case domain.ErrPRNotFound:
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
-		default:
-			writeError(w, http.StatusInternalServerError, "NOT_FOUND", err.Error())
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
@@ -68,25 +102,20 @@ func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 
 func (h *PRHandler) ReassignPR(w http.ResponseWriter, r *http.Request) {
 	var req dto.ReassignPRRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "NOT_FOUND", "invalid request body")
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	v.id("pull_request_id", req.PullRequestID)
+	v.id("old_reviewer_id", req.OldUserID)
+	if !v.check(w) {
 		return
 	}
 
 	newUserID, pr, err := h.prService.ReassignPR(r.Context(), req.PullRequestID, req.OldUserID)
 	if err != nil {
-		switch err {
-		case domain.ErrPRNotFound, domain.ErrUserNotFound:
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
-		case domain.ErrPRMerged:
-			writeError(w, http.StatusConflict, "PR_MERGED", "cannot reassign on merged PR")
-		case domain.ErrNotAssigned:
-			writeError(w, http.StatusConflict, "NOT_ASSIGNED", "cannot reassign on merged PR")
-		case domain.ErrNoCandidate:
-			writeError(w, http.StatusConflict, "NO_CANDIDATE", "cannot reassign on merged PR")
-		default:
-			writeError(w, http.StatusInternalServerError, "NOT_FOUND", err.Error())
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
@@ -100,19 +129,200 @@ func (h *PRHandler) ReassignPR(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(reassignResponse)
 }
 
-func (h *PRHandler) domainPRToDTO(pr *domain.PullRequest) dto.PullRequest {
-	var mergedAt *string
-	if pr.MergedAt != nil {
-		formatted := pr.MergedAt.Format(time.RFC3339)
-		mergedAt = &formatted
+func (h *PRHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req dto.SubmitReviewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	v.id("pull_request_id", req.PullRequestID)
+	v.id("user_id", req.UserID)
+	v.required("state", req.State)
+	if !v.check(w) {
+		return
+	}
+
+	review, err := h.prService.SubmitReview(r.Context(), req)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.SubmitReviewResponse{
+		Review: dto.Review{
+			PullRequestID: review.PullRequestID,
+			UserID:        review.UserID,
+			State:         string(review.State),
+			SubmittedAt:   review.SubmittedAt.Format(time.RFC3339),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *PRHandler) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreatePRRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	validatePreviewAssignment(&v, req)
+	if !v.check(w) {
+		return
+	}
+
+	plan, err := h.prService.PreviewAssignment(r.Context(), req)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.PreviewAssignmentResponse{
+		TeamName:  plan.TeamName,
+		Cursor:    plan.Cursor,
+		Reviewers: []dto.CandidateDecision{},
+		Excluded:  []dto.CandidateDecision{},
+	}
+
+	for _, decision := range plan.Decisions {
+		if decision.Chosen {
+			response.Reviewers = append(response.Reviewers, decisionToDTO(decision))
+		} else {
+			response.Excluded = append(response.Excluded, decisionToDTO(decision))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *PRHandler) GetAssignmentHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	var v validator
+	v.id("pull_request_id", prID)
+	if !v.check(w) {
+		return
 	}
 
+	records, err := h.prService.GetAssignmentHistory(r.Context(), prID)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.GetAssignmentHistoryResponse{
+		PullRequestID: prID,
+		History:       make([]dto.AssignmentRecord, len(records)),
+	}
+
+	for i, record := range records {
+		response.History[i] = dto.AssignmentRecord{
+			UserID:    record.UserID,
+			TeamName:  record.TeamName,
+			Event:     string(record.Event),
+			Reason:    string(record.Reason),
+			Actor:     record.Actor,
+			CreatedAt: record.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *PRHandler) ListPRs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := dto.ListPRsRequest{
+		Status:           query.Get("status"),
+		AuthorID:         query.Get("author_id"),
+		ReviewerID:       query.Get("reviewer_id"),
+		TeamName:         query.Get("team_name"),
+		Query:            query.Get("q"),
+		CreatedFrom:      query.Get("created_from"),
+		CreatedTo:        query.Get("created_to"),
+		MergedFrom:       query.Get("merged_from"),
+		MergedTo:         query.Get("merged_to"),
+		UpdatedFrom:      query.Get("updated_from"),
+		UpdatedTo:        query.Get("updated_to"),
+		LastAssignedFrom: query.Get("last_assigned_from"),
+		LastAssignedTo:   query.Get("last_assigned_to"),
+		SortBy:           query.Get("sort_by"),
+		Order:            query.Get("order"),
+		Limit:            query.Get("limit"),
+		Cursor:           query.Get("cursor"),
+	}
+
+	var v validator
+	v.optionalID("author_id", req.AuthorID)
+	v.optionalID("reviewer_id", req.ReviewerID)
+	v.optionalName("team_name", req.TeamName)
+	v.optionalName("q", req.Query)
+	if !v.check(w) {
+		return
+	}
+
+	prs, nextCursor, err := h.prService.ListPRs(r.Context(), req)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.ListPRsResponse{
+		PullRequests: make([]dto.PullRequest, len(prs)),
+		NextCursor:   nextCursor,
+	}
+
+	for i, pr := range prs {
+		response.PullRequests[i] = h.domainPRToDTO(pr)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *PRHandler) domainPRToDTO(pr *domain.PullRequest) dto.PullRequest {
 	return dto.PullRequest{
-		PullRequestID:     pr.PullRequestID,
-		PullRequestName:   pr.PullRequestName,
-		AuthorID:          pr.AuthorID,
-		Status:            string(pr.Status),
-		AssignedReviewers: pr.AssignedReviewers,
-		MergedAt:          mergedAt,
+		PullRequestID:       pr.PullRequestID,
+		PullRequestName:     pr.PullRequestName,
+		AuthorID:            pr.AuthorID,
+		CoAuthors:           pr.CoAuthors,
+		Status:              string(pr.Status),
+		AssignedReviewers:   pr.AssignedReviewers,
+		ParentPullRequestID: pr.ParentPullRequestID,
+		CreatedAt:           formatTime(&pr.CreatedAt),
+		UpdatedAt:           formatTime(&pr.UpdatedAt),
+		LastAssignedAt:      formatTime(pr.LastAssignedAt),
+		MergedAt:            formatTime(pr.MergedAt),
+	}
+}
+
+// formatTime renders t as RFC3339, treating nil and the zero time as absent.
+func formatTime(t *time.Time) *string {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+func decisionToDTO(decision domain.CandidateDecision) dto.CandidateDecision {
+	return dto.CandidateDecision{
+		UserID:   decision.UserID,
+		Position: decision.Position,
+		Chosen:   decision.Chosen,
+		Reason:   string(decision.Reason),
+	}
+}
+
+func participantToDTO(participant domain.PRParticipant) dto.User {
+	return dto.User{
+		UserID:    participant.User.UserID,
+		Username:  participant.User.Username,
+		TeamName:  participant.TeamName,
+		IsActive:  participant.User.IsActive,
+		Seniority: string(participant.User.Seniority),
 	}
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"pull_requests_service/internal/domain"
@@ -18,19 +19,19 @@ func NewTeamHandler(teamService *service.TeamService) *TeamHandler {
 
 func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 	var req dto.AddTeamRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "NOT_FOUND", "invalid request body")
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	validateAddTeam(&v, req)
+	if !v.check(w) {
 		return
 	}
 
 	team, err := h.teamService.AddTeam(r.Context(), req)
 	if err != nil {
-		switch err {
-		case domain.ErrTeamExists:
-			writeError(w, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists")
-		default:
-			writeError(w, http.StatusInternalServerError, "NOT_FOUND", err.Error())
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
@@ -43,9 +44,11 @@ func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 
 	for i, member := range team.Members {
 		response.Team.Members[i] = dto.TeamMember{
-			UserID:   member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
+			UserID:         member.UserID,
+			Username:       member.Username,
+			IsActive:       member.IsActive,
+			Seniority:      string(member.Seniority),
+			ReviewCapacity: member.ReviewCapacity,
 		}
 	}
 
@@ -56,19 +59,15 @@ func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 
 func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	teamName := r.URL.Query().Get("team_name")
-	if teamName == "" {
-		writeError(w, http.StatusBadRequest, "NOT_FOUND", "team_name is required")
+	var v validator
+	v.name("team_name", teamName)
+	if !v.check(w) {
 		return
 	}
 
 	team, err := h.teamService.GetTeam(r.Context(), teamName)
 	if err != nil {
-		switch err {
-		case domain.ErrTeamNotFound:
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
-		default:
-			writeError(w, http.StatusInternalServerError, "NOT_FOUND", err.Error())
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
@@ -79,9 +78,124 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 
 	for i, member := range team.Members {
 		response.Members[i] = dto.TeamMember{
-			UserID:   member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
+			UserID:         member.UserID,
+			Username:       member.Username,
+			IsActive:       member.IsActive,
+			Seniority:      string(member.Seniority),
+			ReviewCapacity: member.ReviewCapacity,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *TeamHandler) AddReviewerExclusion(w http.ResponseWriter, r *http.Request) {
+	h.changeReviewerExclusion(w, r, h.teamService.AddReviewerExclusion)
+}
+
+func (h *TeamHandler) RemoveReviewerExclusion(w http.ResponseWriter, r *http.Request) {
+	h.changeReviewerExclusion(w, r, h.teamService.RemoveReviewerExclusion)
+}
+
+func (h *TeamHandler) changeReviewerExclusion(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(context.Context, dto.ReviewerExclusionRequest) (*domain.ReviewerExclusion, error),
+) {
+	var req dto.ReviewerExclusionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	v.name("team_name", req.TeamName)
+	v.id("user_id", req.UserID)
+	v.id("other_user_id", req.OtherUserID)
+	if !v.check(w) {
+		return
+	}
+
+	exclusion, err := change(r.Context(), req)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.ReviewerExclusionResponse{
+		TeamName: exclusion.TeamName,
+		Exclusion: dto.ReviewerExclusion{
+			UserID:      exclusion.UserID1,
+			OtherUserID: exclusion.UserID2,
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *TeamHandler) GetReviewerExclusions(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	var v validator
+	v.name("team_name", teamName)
+	if !v.check(w) {
+		return
+	}
+
+	exclusions, err := h.teamService.GetReviewerExclusions(r.Context(), teamName)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.GetReviewerExclusionsResponse{
+		TeamName:   teamName,
+		Exclusions: make([]dto.ReviewerExclusion, len(exclusions)),
+	}
+
+	for i, exclusion := range exclusions {
+		response.Exclusions[i] = dto.ReviewerExclusion{
+			UserID:      exclusion.UserID1,
+			OtherUserID: exclusion.UserID2,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *TeamHandler) GetTeamLoad(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	var v validator
+	v.name("team_name", teamName)
+	if !v.check(w) {
+		return
+	}
+
+	loads, err := h.teamService.GetTeamLoad(r.Context(), teamName)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.GetTeamLoadResponse{
+		TeamName: teamName,
+		Members:  make([]dto.MemberLoad, len(loads)),
+	}
+
+	for i, load := range loads {
+		response.Members[i] = dto.MemberLoad{
+			TeamMember: dto.TeamMember{
+				UserID:         load.UserID,
+				Username:       load.Username,
+				IsActive:       load.IsActive,
+				Seniority:      string(load.Seniority),
+				ReviewCapacity: load.ReviewCapacity,
+			},
+			OpenReviews:         load.OpenReviews,
+			ReviewsLast7Days:    load.ReviewsLast7Days,
+			ReviewsLast30Days:   load.ReviewsLast30Days,
+			CapacityUtilization: load.CapacityUtilization(),
 		}
 	}
 
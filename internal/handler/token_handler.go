@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/service"
+	"time"
+)
+
+type TokenHandler struct {
+	tokenService *service.TokenService
+}
+
+func NewTokenHandler(tokenService *service.TokenService) *TokenHandler {
+	return &TokenHandler{tokenService: tokenService}
+}
+
+func (h *TokenHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateTokenRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	v.name("name", req.Name)
+	v.required("role", req.Role)
+	v.optionalID("user_id", req.UserID)
+	if !v.check(w) {
+		return
+	}
+
+	token, apiToken, err := h.tokenService.CreateToken(r.Context(), req)
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.CreateTokenResponse{
+		Token:     token,
+		TokenInfo: tokenToDTO(apiToken),
+	})
+}
+
+func (h *TokenHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	var req dto.RevokeTokenRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	if req.TokenID <= 0 {
+		v.add("token_id", "REQUIRED", "token_id must be a positive integer")
+	}
+	if !v.check(w) {
+		return
+	}
+
+	if err := h.tokenService.RevokeToken(r.Context(), req.TokenID); err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.RevokeTokenResponse{TokenID: req.TokenID, Revoked: true})
+}
+
+func (h *TokenHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.tokenService.ListTokens(r.Context())
+	if err != nil {
+		writeDomainError(w, r, err)
+		return
+	}
+
+	response := dto.ListTokensResponse{Tokens: make([]dto.APIToken, len(tokens))}
+	for i := range tokens {
+		response.Tokens[i] = tokenToDTO(&tokens[i])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func tokenToDTO(token *domain.APIToken) dto.APIToken {
+	return dto.APIToken{
+		TokenID:   token.TokenID,
+		Name:      token.Name,
+		Role:      string(token.Role),
+		UserID:    token.UserID,
+		CreatedAt: token.CreatedAt.Format(time.RFC3339),
+		RevokedAt: formatTime(token.RevokedAt),
+	}
+}
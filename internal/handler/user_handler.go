@@ -3,7 +3,6 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
-	"pull_requests_service/internal/domain"
 	"pull_requests_service/internal/dto"
 	"pull_requests_service/internal/service"
 )
@@ -18,28 +17,29 @@ func NewUserHandler(userService *service.UserService) *UserHandler {
 
 func (h *UserHandler) SetUserActive(w http.ResponseWriter, r *http.Request) {
 	var req dto.SetUserActiveRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "NOT_FOUND", "resource not found")
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var v validator
+	v.id("user_id", req.UserID)
+	if !v.check(w) {
 		return
 	}
 
 	user, teamName, err := h.userService.SetUserActive(r.Context(), req.UserID, req.IsActive)
 	if err != nil {
-		switch err {
-		case domain.ErrUserNotFound:
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
-		default:
-			writeError(w, http.StatusInternalServerError, "NOT_FOUND", err.Error())
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
 	response := dto.SetUserActiveResponse{
 		User: dto.User{
-			UserID:   user.UserID,
-			Username: user.Username,
-			TeamName: teamName,
-			IsActive: user.IsActive,
+			UserID:    user.UserID,
+			Username:  user.Username,
+			TeamName:  teamName,
+			IsActive:  user.IsActive,
+			Seniority: string(user.Seniority),
 		},
 	}
 
@@ -49,19 +49,15 @@ func (h *UserHandler) SetUserActive(w http.ResponseWriter, r *http.Request) {
 
 func (h *UserHandler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		writeError(w, http.StatusBadRequest, "NOT_FOUND", "user_id is required")
+	var v validator
+	v.id("user_id", userID)
+	if !v.check(w) {
 		return
 	}
 
 	prs, err := h.userService.GetUserReviews(r.Context(), userID)
 	if err != nil {
-		switch err {
-		case domain.ErrUserNotFound:
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
-		default:
-			writeError(w, http.StatusInternalServerError, "NOT_FOUND", err.Error())
-		}
+		writeDomainError(w, r, err)
 		return
 	}
 
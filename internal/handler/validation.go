@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"pull_requests_service/internal/dto"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// maxBodySize bounds JSON request bodies other than imports and restores.
+	maxBodySize = 1 << 20
+	// maxFieldLength matches the VARCHAR(256) columns ids and names are
+	// stored in.
+	maxFieldLength = 256
+)
+
+// idPattern is what newly created user and PR ids may look like: no spaces
+// or control characters, starting with a letter or digit. Ids that already
+// exist are looked up as they are, whatever they look like.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:@/-]*$`)
+
+// decodeJSON decodes the request body into target and answers 400
+// INVALID_REQUEST when the body is not valid JSON.
+func decodeJSON(w http.ResponseWriter, r *http.Request, target any) bool {
+	return decodeJSONLimit(w, r, target, maxBodySize)
+}
+
+func decodeJSONLimit(w http.ResponseWriter, r *http.Request, target any, limit int64) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit)).Decode(target)
+	if err == nil {
+		return true
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeError(w, http.StatusRequestEntityTooLarge, "INVALID_REQUEST",
+			fmt.Sprintf("request body must not exceed %d bytes", maxBytesErr.Limit))
+		return false
+	}
+	writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "request body is not valid JSON: "+err.Error())
+	return false
+}
+
+// validator collects per-field problems of a request so the client gets all
+// of them in one response instead of fixing them one round-trip at a time.
+type validator struct {
+	fields []dto.FieldError
+}
+
+func (v *validator) add(field, code, message string) {
+	v.fields = append(v.fields, dto.FieldError{Field: field, Code: code, Message: message})
+}
+
+// id checks a required reference to an existing identifier.
+func (v *validator) id(field, value string) {
+	if value == "" {
+		v.add(field, "REQUIRED", field+" is required")
+		return
+	}
+	v.optionalID(field, value)
+}
+
+// optionalID checks a reference to an existing identifier that may be
+// omitted.
+func (v *validator) optionalID(field, value string) {
+	if len(value) > maxFieldLength {
+		v.add(field, "TOO_LONG", fmt.Sprintf("%s must be at most %d bytes", field, maxFieldLength))
+	}
+}
+
+// newID checks an identifier the request creates, which must also match
+// idPattern.
+func (v *validator) newID(field, value string) {
+	switch {
+	case value == "":
+		v.add(field, "REQUIRED", field+" is required")
+	case len(value) > maxFieldLength:
+		v.add(field, "TOO_LONG", fmt.Sprintf("%s must be at most %d bytes", field, maxFieldLength))
+	case !idPattern.MatchString(value):
+		v.add(field, "INVALID_FORMAT", field+" must start with a letter or digit and contain only letters, digits and ._:@/-")
+	}
+}
+
+// name checks a required human-readable name such as a team or PR title.
+func (v *validator) name(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "REQUIRED", field+" is required")
+		return
+	}
+	v.optionalName(field, value)
+}
+
+// optionalName checks a free-text value that may be omitted.
+func (v *validator) optionalName(field, value string) {
+	switch {
+	case value == "":
+	case len(value) > maxFieldLength:
+		v.add(field, "TOO_LONG", fmt.Sprintf("%s must be at most %d bytes", field, maxFieldLength))
+	case !utf8.ValidString(value) || strings.IndexFunc(value, unicode.IsControl) >= 0:
+		v.add(field, "INVALID_FORMAT", field+" must be valid UTF-8 without control characters")
+	}
+}
+
+// required checks that an enumerated value is present; its allowed values
+// are checked by the service.
+func (v *validator) required(field, value string) {
+	if value == "" {
+		v.add(field, "REQUIRED", field+" is required")
+	}
+}
+
+// check answers 400 VALIDATION_FAILED listing every collected problem and
+// reports whether the request was valid.
+func (v *validator) check(w http.ResponseWriter) bool {
+	if len(v.fields) == 0 {
+		return true
+	}
+
+	writeJSON(w, http.StatusBadRequest, dto.NewValidationErrorResponse(v.fields))
+	return false
+}
+
+func validateAddTeam(v *validator, req dto.AddTeamRequest) {
+	v.name("team_name", req.TeamName)
+
+	seen := make(map[string]bool, len(req.Members))
+	for i, member := range req.Members {
+		prefix := fmt.Sprintf("members[%d].", i)
+		v.newID(prefix+"user_id", member.UserID)
+		v.name(prefix+"username", member.Username)
+
+		if member.UserID != "" && seen[member.UserID] {
+			v.add(prefix+"user_id", "DUPLICATE", "user_id "+member.UserID+" is listed more than once")
+		}
+		seen[member.UserID] = true
+	}
+}
+
+func validateCreatePR(v *validator, req dto.CreatePRRequest) {
+	v.newID("pull_request_id", req.PullRequestID)
+	v.name("pull_request_name", req.PullRequestName)
+	validatePRParticipants(v, req)
+}
+
+// validatePreviewAssignment is validateCreatePR for a PR that does not exist
+// yet, so its id and name may be left out.
+func validatePreviewAssignment(v *validator, req dto.CreatePRRequest) {
+	if req.PullRequestID != "" {
+		v.newID("pull_request_id", req.PullRequestID)
+	}
+	v.optionalName("pull_request_name", req.PullRequestName)
+	validatePRParticipants(v, req)
+}
+
+func validatePRParticipants(v *validator, req dto.CreatePRRequest) {
+	v.id("author_id", req.AuthorID)
+	for i, coAuthor := range req.CoAuthors {
+		v.id(fmt.Sprintf("co_authors[%d]", i), coAuthor)
+	}
+	v.optionalID("parent_pull_request_id", req.ParentPullRequestID)
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"pull_requests_service/internal/dto"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fieldCodes lists the collected problems as "field:CODE" for comparison.
+func fieldCodes(v *validator) []string {
+	var codes []string
+	for _, field := range v.fields {
+		codes = append(codes, field.Field+":"+field.Code)
+	}
+	return codes
+}
+
+func TestValidateCreatePR(t *testing.T) {
+	tooLong := strings.Repeat("a", maxFieldLength+1)
+
+	tests := []struct {
+		name string
+		req  dto.CreatePRRequest
+		want []string
+	}{
+		{
+			name: "valid",
+			req:  dto.CreatePRRequest{PullRequestID: "pr-1001", PullRequestName: "Add search", AuthorID: "u1"},
+		},
+		{
+			name: "all problems are reported at once",
+			req:  dto.CreatePRRequest{PullRequestName: " "},
+			want: []string{"pull_request_id:REQUIRED", "pull_request_name:REQUIRED", "author_id:REQUIRED"},
+		},
+		{
+			name: "new id must match the pattern",
+			req:  dto.CreatePRRequest{PullRequestID: "pr 1001", PullRequestName: "Add search", AuthorID: "u1"},
+			want: []string{"pull_request_id:INVALID_FORMAT"},
+		},
+		{
+			name: "existing ids are taken as they are",
+			req: dto.CreatePRRequest{
+				PullRequestID:       "pr-1001",
+				PullRequestName:     "Add search",
+				AuthorID:            "legacy user",
+				CoAuthors:           []string{"_bot"},
+				ParentPullRequestID: "old pr #7",
+			},
+		},
+		{
+			name: "long references",
+			req: dto.CreatePRRequest{
+				PullRequestID:       "pr-1001",
+				PullRequestName:     "Add search",
+				AuthorID:            tooLong,
+				CoAuthors:           []string{"u2", ""},
+				ParentPullRequestID: tooLong,
+			},
+			want: []string{"author_id:TOO_LONG", "co_authors[1]:REQUIRED", "parent_pull_request_id:TOO_LONG"},
+		},
+		{
+			name: "control characters in the name",
+			req:  dto.CreatePRRequest{PullRequestID: "pr-1001", PullRequestName: "Add\x00search", AuthorID: "u1"},
+			want: []string{"pull_request_name:INVALID_FORMAT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v validator
+			validateCreatePR(&v, tt.req)
+			if got := fieldCodes(&v); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePreviewAssignment(t *testing.T) {
+	tests := []struct {
+		name string
+		req  dto.CreatePRRequest
+		want []string
+	}{
+		{
+			name: "id and name may be left out",
+			req:  dto.CreatePRRequest{AuthorID: "u1"},
+		},
+		{
+			name: "a given id must still match the pattern",
+			req:  dto.CreatePRRequest{PullRequestID: "-pr", AuthorID: "u1"},
+			want: []string{"pull_request_id:INVALID_FORMAT"},
+		},
+		{
+			name: "author is still required",
+			req:  dto.CreatePRRequest{PullRequestName: "Add search"},
+			want: []string{"author_id:REQUIRED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v validator
+			validatePreviewAssignment(&v, tt.req)
+			if got := fieldCodes(&v); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAddTeam(t *testing.T) {
+	tests := []struct {
+		name string
+		req  dto.AddTeamRequest
+		want []string
+	}{
+		{
+			name: "valid",
+			req: dto.AddTeamRequest{TeamName: "backend", Members: []dto.TeamMember{
+				{UserID: "u1", Username: "Alice"},
+				{UserID: "alice@example.com", Username: "Alice E."},
+			}},
+		},
+		{
+			name: "team without members",
+			req:  dto.AddTeamRequest{TeamName: "backend"},
+		},
+		{
+			name: "member problems are prefixed with their index",
+			req: dto.AddTeamRequest{Members: []dto.TeamMember{
+				{UserID: "u1", Username: "Alice"},
+				{UserID: "u 2"},
+				{UserID: "u1", Username: "Alice again"},
+			}},
+			want: []string{
+				"team_name:REQUIRED",
+				"members[1].user_id:INVALID_FORMAT",
+				"members[1].username:REQUIRED",
+				"members[2].user_id:DUPLICATE",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v validator
+			validateAddTeam(&v, tt.req)
+			if got := fieldCodes(&v); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantOK     bool
+		wantStatus int
+	}{
+		{name: "valid", body: `{"team_name":"backend"}`, wantOK: true, wantStatus: http.StatusOK},
+		{name: "malformed", body: `{"team_name":`, wantStatus: http.StatusBadRequest},
+		{name: "wrong type", body: `{"team_name":1}`, wantStatus: http.StatusBadRequest},
+		{name: "too large", body: `{"team_name":"` + strings.Repeat("a", maxBodySize) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(tt.body))
+
+			var req dto.AddTeamRequest
+			if ok := decodeJSON(w, r, &req); ok != tt.wantOK {
+				t.Errorf("decodeJSON() = %v, want %v", ok, tt.wantOK)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
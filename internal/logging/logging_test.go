@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		level   string
+		format  string
+		wantErr string
+	}{
+		{level: "debug", format: "json"},
+		{level: "INFO", format: "text"},
+		{level: "warn", format: "JSON"},
+		{level: "error", format: "text"},
+		{level: "verbose", format: "json", wantErr: `invalid log level "verbose"`},
+		{level: "", format: "json", wantErr: `invalid log level ""`},
+		{level: "info", format: "logfmt", wantErr: `invalid log format "logfmt"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level+"/"+tt.format, func(t *testing.T) {
+			logger, err := New(&bytes.Buffer{}, tt.level, tt.format)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("New() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || logger == nil {
+				t.Fatalf("New() = %v, %v", logger, err)
+			}
+		})
+	}
+}
+
+func TestNewFiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "warn", "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	if out := buf.String(); strings.Contains(out, "dropped") || !strings.Contains(out, "kept") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestRequestIDAndTraceInRecords(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger = logger.With("component", "test")
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01, 0x02},
+		SpanID:  trace.SpanID{0x03},
+	})
+	ctx := WithRequestID(context.Background(), "req-1")
+	ctx = trace.ContextWithSpanContext(ctx, spanContext)
+
+	logger.InfoContext(ctx, "with context")
+	logger.Info("without context")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d records, want 2: %s", len(lines), buf.String())
+	}
+
+	var with, without map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &with); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &without); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"request_id": "req-1",
+		"trace_id":   spanContext.TraceID().String(),
+		"span_id":    spanContext.SpanID().String(),
+		"component":  "test",
+	}
+	for key, value := range want {
+		if with[key] != value {
+			t.Errorf("%s = %v, want %q", key, with[key], value)
+		}
+	}
+	for _, key := range []string{"request_id", "trace_id", "span_id"} {
+		if _, ok := without[key]; ok {
+			t.Errorf("record without a context has %s", key)
+		}
+	}
+}
+
+func TestRequestIDMissing(t *testing.T) {
+	if got := RequestID(context.Background()); got != "" {
+		t.Errorf("RequestID() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,84 @@
+// Package metrics defines the service's Prometheus metrics and the registry
+// served on /metrics.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_service"
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	PRsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_created_total",
+		Help:      "Pull requests created.",
+	})
+
+	PRsMerged = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_merged_total",
+		Help:      "Pull requests merged.",
+	})
+
+	MergesBlocked = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "merges_blocked_total",
+		Help:      "Merges rejected by the team merge policy.",
+	})
+
+	Reassignments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reassignments_total",
+		Help:      "Reviewer reassignments by selection reason of the new reviewer.",
+	}, []string{"reason"})
+
+	NoCandidate = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "no_candidate_total",
+		Help:      "Reassignments that failed because no replacement reviewer was available.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		PRsCreated,
+		PRsMerged,
+		MergesBlocked,
+		Reassignments,
+		NoCandidate,
+	)
+}
+
+// RegisterDB exposes the connection pool statistics of db.
+func RegisterDB(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerServesServiceMetrics(t *testing.T) {
+	PRsCreated.Inc()
+	Reassignments.WithLabelValues("ROTATION").Inc()
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"pr_service_pull_requests_created_total",
+		`pr_service_reassignments_total{reason="ROTATION"}`,
+		"go_goroutines",
+		"process_",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics lacks %s", want)
+		}
+	}
+}
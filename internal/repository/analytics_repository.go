@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"pull_requests_service/internal/domain"
+	"strings"
+	"time"
+)
+
+type analyticsRepository struct {
+	BaseRepository
+}
+
+func NewAnalyticsRepository(db *sql.DB, timeout time.Duration) domain.AnalyticsRepository {
+	return &analyticsRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+// GetReviewLatency buckets PRs by the author's team and the week they were
+// opened. Rows with a NULL week are the per-team totals produced by the
+// grouping sets.
+func (r *analyticsRepository) GetReviewLatency(ctx context.Context, filter domain.ReviewLatencyFilter) ([]domain.ReviewLatencyStats, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.TeamName != "" {
+		conditions = append(conditions, "tm.team_name = "+arg(filter.TeamName))
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "pr.created_at >= "+arg(*filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "pr.created_at < "+arg(*filter.To))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	assigned, unassigned, reassigned := arg(domain.EventAssigned), arg(domain.EventUnassigned), arg(domain.ReasonReassigned)
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        WITH team_prs AS (
+            SELECT pr.pull_request_id, tm.team_name, pr.created_at, pr."mergedAt",
+                   date_trunc('week', pr.created_at) AS week_start
+            FROM pull_request pr
+            JOIN team_member tm ON tm.user_id = pr.author_id
+            `+where+`
+        ),
+        ranked_assignments AS (
+            SELECT h.pull_request_id, h.created_at,
+                   ROW_NUMBER() OVER (PARTITION BY h.pull_request_id ORDER BY h.created_at, h.id) AS position
+            FROM pull_request_history h
+            WHERE h.event = `+assigned+`
+        ),
+        reassignments AS (
+            SELECT pull_request_id, COUNT(*) AS reassign_count
+            FROM pull_request_history
+            WHERE event = `+unassigned+` AND reason = `+reassigned+`
+            GROUP BY pull_request_id
+        )
+        SELECT p.team_name, p.week_start,
+               COUNT(*),
+               COUNT(p."mergedAt"),
+               COUNT(ra.pull_request_id),
+               COALESCE(SUM(ra.reassign_count), 0),
+               percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.created_at - p.created_at)),
+               percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.created_at - p.created_at)),
+               percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM p."mergedAt" - p.created_at)),
+               percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM p."mergedAt" - p.created_at))
+        FROM team_prs p
+        LEFT JOIN ranked_assignments a ON a.pull_request_id = p.pull_request_id AND a.position = 1
+        LEFT JOIN reassignments ra ON ra.pull_request_id = p.pull_request_id
+        GROUP BY GROUPING SETS ((p.team_name, p.week_start), (p.team_name))
+        ORDER BY p.team_name, p.week_start NULLS FIRST`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []domain.ReviewLatencyStats
+	for rows.Next() {
+		var s domain.ReviewLatencyStats
+		var weekStart sql.NullTime
+		var assignP50, assignP90, mergeP50, mergeP90 sql.NullFloat64
+
+		err := rows.Scan(&s.TeamName, &weekStart, &s.PRsCreated, &s.PRsMerged, &s.PRsReassigned, &s.Reassignments,
+			&assignP50, &assignP90, &mergeP50, &mergeP90)
+		if err != nil {
+			return nil, err
+		}
+
+		if weekStart.Valid {
+			s.WeekStart = &weekStart.Time
+		}
+		s.TimeToFirstAssignmentP50 = nullFloat(assignP50)
+		s.TimeToFirstAssignmentP90 = nullFloat(assignP90)
+		s.TimeToMergeP50 = nullFloat(mergeP50)
+		s.TimeToMergeP90 = nullFloat(mergeP90)
+
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+func nullFloat(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pull_requests_service/internal/domain"
+	"time"
+)
+
+type assignmentRepository struct {
+	BaseRepository
+}
+
+func NewAssignmentRepository(db *sql.DB, timeout time.Duration) domain.AssignmentRepository {
+	return &assignmentRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+func (r *assignmentRepository) LockRotation(ctx context.Context, teamName string) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	// The row may not exist before the first assignment in the team, and
+	// there would be nothing to lock.
+	_, err := r.conn(ctx).ExecContext(ctx, `
+        INSERT INTO team_rotation (team_name, last_user_id)
+        VALUES ($1, '')
+        ON CONFLICT (team_name) DO NOTHING`,
+		teamName,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn(ctx).ExecContext(ctx, "SELECT 1 FROM team_rotation WHERE team_name = $1 FOR UPDATE", teamName)
+	return err
+}
+
+func (r *assignmentRepository) GetRotationCursor(ctx context.Context, teamName string) (string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var userID string
+	err := r.conn(ctx).QueryRowContext(ctx, "SELECT last_user_id FROM team_rotation WHERE team_name = $1", teamName).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return userID, err
+}
+
+func (r *assignmentRepository) SetRotationCursor(ctx context.Context, teamName, userID string) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).ExecContext(ctx, `
+        INSERT INTO team_rotation (team_name, last_user_id, updated_at)
+        VALUES ($1, $2, NOW())
+        ON CONFLICT (team_name)
+        DO UPDATE SET last_user_id = EXCLUDED.last_user_id, updated_at = EXCLUDED.updated_at`,
+		teamName, userID,
+	)
+	return err
+}
+
+func (r *assignmentRepository) RecordAssignments(ctx context.Context, records []domain.AssignmentRecord) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, record := range records {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO pull_request_history (pull_request_id, team_name, user_id, event, reason, actor, created_at)
+            VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)`,
+			record.PullRequestID, record.TeamName, record.UserID, record.Event, record.Reason, record.Actor, record.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *assignmentRepository) GetAssignmentHistory(ctx context.Context, prID string) ([]domain.AssignmentRecord, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT pull_request_id, team_name, user_id, event, reason, COALESCE(actor, ''), created_at
+        FROM pull_request_history
+        WHERE pull_request_id = $1
+        ORDER BY id`,
+		prID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []domain.AssignmentRecord
+	for rows.Next() {
+		var record domain.AssignmentRecord
+		if err := rows.Scan(&record.PullRequestID, &record.TeamName, &record.UserID, &record.Event, &record.Reason, &record.Actor, &record.CreatedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pull_requests_service/internal/domain"
+	"time"
+)
+
+type backupRepository struct {
+	BaseRepository
+}
+
+func NewBackupRepository(db *sql.DB, timeout time.Duration) domain.BackupRepository {
+	return &backupRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+func (r *backupRepository) Export(ctx context.Context) (*domain.Archive, error) {
+	// A repeatable read snapshot keeps the tables consistent with each other.
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	archive := &domain.Archive{}
+
+	steps := []func(context.Context, *sql.Tx, *domain.Archive) error{
+		exportUsers,
+		exportTeams,
+		exportMergePolicies,
+		exportReviewerExclusions,
+		exportRotationCursors,
+		exportPullRequests,
+		exportReviews,
+		exportHistory,
+		exportActivityHistory,
+	}
+	// Each table is bounded by the DB timeout on its own rather than the whole
+	// export, which grows with the database.
+	for _, step := range steps {
+		stepCtx, cancel := r.withTimeout(ctx)
+		err := step(stepCtx, tx, archive)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return archive, tx.Commit()
+}
+
+func exportUsers(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT user_id, username, is_active, seniority, review_capacity
+        FROM "user" ORDER BY user_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user domain.TeamMember
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &user.Seniority, &user.ReviewCapacity); err != nil {
+			return err
+		}
+		archive.Users = append(archive.Users, user)
+	}
+
+	return rows.Err()
+}
+
+func exportTeams(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT t.team_name, tm.user_id
+        FROM team t
+        LEFT JOIN team_member tm ON tm.team_name = t.team_name
+        ORDER BY t.team_name, tm.user_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamName string
+		var userID sql.NullString
+		if err := rows.Scan(&teamName, &userID); err != nil {
+			return err
+		}
+
+		teams := archive.Teams
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			archive.Teams = append(archive.Teams, domain.TeamMembership{TeamName: teamName})
+		}
+		if userID.Valid {
+			team := &archive.Teams[len(archive.Teams)-1]
+			team.UserIDs = append(team.UserIDs, userID.String)
+		}
+	}
+
+	return rows.Err()
+}
+
+func exportMergePolicies(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT team_name, min_approvals, block_on_changes_requested, require_senior_approval, forbid_self_approval
+        FROM team_merge_policy ORDER BY team_name`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var policy domain.MergePolicy
+		err := rows.Scan(&policy.TeamName, &policy.MinApprovals, &policy.BlockOnChangesRequested,
+			&policy.RequireSeniorApproval, &policy.ForbidSelfApproval)
+		if err != nil {
+			return err
+		}
+		archive.MergePolicies = append(archive.MergePolicies, policy)
+	}
+
+	return rows.Err()
+}
+
+func exportReviewerExclusions(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT team_name, user_id_1, user_id_2
+        FROM team_reviewer_exclusion ORDER BY team_name, user_id_1, user_id_2`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var exclusion domain.ReviewerExclusion
+		if err := rows.Scan(&exclusion.TeamName, &exclusion.UserID1, &exclusion.UserID2); err != nil {
+			return err
+		}
+		archive.ReviewerExclusions = append(archive.ReviewerExclusions, exclusion)
+	}
+
+	return rows.Err()
+}
+
+func exportRotationCursors(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, "SELECT team_name, last_user_id FROM team_rotation ORDER BY team_name")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cursor domain.RotationCursor
+		if err := rows.Scan(&cursor.TeamName, &cursor.UserID); err != nil {
+			return err
+		}
+		archive.RotationCursors = append(archive.RotationCursors, cursor)
+	}
+
+	return rows.Err()
+}
+
+func exportPullRequests(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT `+prColumns+`
+        FROM pull_request ORDER BY created_at, pull_request_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	index := make(map[string]*domain.PullRequest)
+	for rows.Next() {
+		pr, err := scanPR(rows)
+		if err != nil {
+			return err
+		}
+		index[pr.PullRequestID] = pr
+		archive.PullRequests = append(archive.PullRequests, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	coAuthors, err := tx.QueryContext(ctx, `
+        SELECT pull_request_id, user_id
+        FROM pull_request_co_author ORDER BY pull_request_id, user_id`)
+	if err != nil {
+		return err
+	}
+	defer coAuthors.Close()
+
+	for coAuthors.Next() {
+		var prID, userID string
+		if err := coAuthors.Scan(&prID, &userID); err != nil {
+			return err
+		}
+		if pr, ok := index[prID]; ok {
+			pr.CoAuthors = append(pr.CoAuthors, userID)
+		}
+	}
+
+	return coAuthors.Err()
+}
+
+func exportReviews(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT pull_request_id, user_id, state, submitted_at
+        FROM pull_request_review ORDER BY pull_request_id, submitted_at`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var review domain.Review
+		if err := rows.Scan(&review.PullRequestID, &review.UserID, &review.State, &review.SubmittedAt); err != nil {
+			return err
+		}
+		archive.Reviews = append(archive.Reviews, review)
+	}
+
+	return rows.Err()
+}
+
+func exportHistory(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT pull_request_id, team_name, user_id, event, reason, COALESCE(actor, ''), created_at
+        FROM pull_request_history ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record domain.AssignmentRecord
+		if err := rows.Scan(&record.PullRequestID, &record.TeamName, &record.UserID, &record.Event, &record.Reason, &record.Actor, &record.CreatedAt); err != nil {
+			return err
+		}
+		archive.History = append(archive.History, record)
+	}
+
+	return rows.Err()
+}
+
+func exportActivityHistory(ctx context.Context, tx *sql.Tx, archive *domain.Archive) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT user_id, team_name, is_active, COALESCE(actor, ''), created_at
+        FROM user_activity_history ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change domain.ActivityChange
+		if err := rows.Scan(&change.UserID, &change.TeamName, &change.IsActive, &change.Actor, &change.CreatedAt); err != nil {
+			return err
+		}
+		archive.ActivityHistory = append(archive.ActivityHistory, change)
+	}
+
+	return rows.Err()
+}
+
+func (r *backupRepository) Restore(ctx context.Context, archive *domain.Archive) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	checkCtx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var notEmpty bool
+	err = tx.QueryRowContext(checkCtx, `
+        SELECT EXISTS(SELECT 1 FROM "user") OR EXISTS(SELECT 1 FROM team) OR EXISTS(SELECT 1 FROM pull_request)`,
+	).Scan(&notEmpty)
+	if err != nil {
+		return err
+	}
+	if notEmpty {
+		return domain.ErrDatabaseNotEmpty
+	}
+
+	for _, user := range archive.Users {
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO "user" (user_id, username, is_active, seniority, review_capacity)
+            VALUES ($1, $2, $3, $4, $5)`,
+			user.UserID, user.Username, user.IsActive, user.Seniority, user.ReviewCapacity,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, team := range archive.Teams {
+		if err = r.execWithTimeout(ctx, tx, "INSERT INTO team (team_name) VALUES ($1)", team.TeamName); err != nil {
+			return err
+		}
+		for _, userID := range team.UserIDs {
+			err = r.execWithTimeout(ctx, tx, "INSERT INTO team_member (team_name, user_id) VALUES ($1, $2)", team.TeamName, userID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, policy := range archive.MergePolicies {
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO team_merge_policy (team_name, min_approvals, block_on_changes_requested, require_senior_approval, forbid_self_approval)
+            VALUES ($1, $2, $3, $4, $5)`,
+			policy.TeamName, policy.MinApprovals, policy.BlockOnChangesRequested, policy.RequireSeniorApproval, policy.ForbidSelfApproval,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, exclusion := range archive.ReviewerExclusions {
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO team_reviewer_exclusion (team_name, user_id_1, user_id_2)
+            VALUES ($1, $2, $3)`,
+			exclusion.TeamName, exclusion.UserID1, exclusion.UserID2,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, cursor := range archive.RotationCursors {
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO team_rotation (team_name, last_user_id, updated_at)
+            VALUES ($1, $2, NOW())`,
+			cursor.TeamName, cursor.UserID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parents are linked after every PR exists so the archive order does not matter.
+	for _, pr := range archive.PullRequests {
+		var reviewer1, reviewer2 sql.NullString
+		if len(pr.AssignedReviewers) > 0 {
+			reviewer1 = sql.NullString{String: pr.AssignedReviewers[0], Valid: true}
+		}
+		if len(pr.AssignedReviewers) > 1 {
+			reviewer2 = sql.NullString{String: pr.AssignedReviewers[1], Valid: true}
+		}
+
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO pull_request (pull_request_id, pull_request_name, author_id, status, reviewer_1, reviewer_2,
+                                      "mergedAt", created_at, updated_at, last_assigned_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, reviewer1, reviewer2,
+			pr.MergedAt, pr.CreatedAt, pr.UpdatedAt, pr.LastAssignedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, coAuthorID := range pr.CoAuthors {
+			err = r.execWithTimeout(ctx, tx, `
+                INSERT INTO pull_request_co_author (pull_request_id, user_id)
+                VALUES ($1, $2)`,
+				pr.PullRequestID, coAuthorID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, pr := range archive.PullRequests {
+		if pr.ParentPullRequestID == "" {
+			continue
+		}
+		err = r.execWithTimeout(ctx, tx, `
+            UPDATE pull_request SET parent_pull_request_id = $1 WHERE pull_request_id = $2`,
+			pr.ParentPullRequestID, pr.PullRequestID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, review := range archive.Reviews {
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO pull_request_review (pull_request_id, user_id, state, submitted_at)
+            VALUES ($1, $2, $3, $4)`,
+			review.PullRequestID, review.UserID, review.State, review.SubmittedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, record := range archive.History {
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO pull_request_history (pull_request_id, team_name, user_id, event, reason, actor, created_at)
+            VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)`,
+			record.PullRequestID, record.TeamName, record.UserID, record.Event, record.Reason, record.Actor, record.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, change := range archive.ActivityHistory {
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO user_activity_history (user_id, team_name, is_active, actor, created_at)
+            VALUES ($1, $2, $3, NULLIF($4, ''), $5)`,
+			change.UserID, change.TeamName, change.IsActive, change.Actor, change.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -1,15 +1,100 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 type BaseRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	timeout time.Duration
 }
 
 func (r *BaseRepository) Close() error {
 	return r.db.Close()
 }
+
+// withTimeout bounds a repository call by the configured DB timeout, on top
+// of the caller's own deadline or cancellation.
+func (r *BaseRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+// execWithTimeout runs one statement of a bulk operation under the DB
+// timeout. Imports and restores bound each statement rather than the whole
+// transaction, whose length grows with the size of the input.
+func (r *BaseRepository) execWithTimeout(ctx context.Context, q querier, query string, args ...any) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := q.ExecContext(ctx, query, args...)
+	return err
+}
+
+// querier is what a repository runs its statements on: the connection pool
+// or a transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction started by the Transactor if ctx carries one,
+// and the connection pool otherwise.
+func (r *BaseRepository) conn(ctx context.Context) querier {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx
+	}
+	return r.db
+}
+
+// scopedTx is a transaction of a single multi-statement write. When the
+// write joins a transaction that ctx carries, Commit and Rollback are left to
+// the owner of that transaction and do nothing here.
+type scopedTx struct {
+	*sql.Tx
+	owned bool
+}
+
+func (t scopedTx) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t scopedTx) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// beginTx starts a transaction for a multi-statement write, or joins the one
+// ctx carries, so the write stays atomic on its own and also takes part in
+// the caller's unit of work.
+func (r *BaseRepository) beginTx(ctx context.Context) (scopedTx, error) {
+	if tx, ok := txFromContext(ctx); ok {
+		return scopedTx{Tx: tx}, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return scopedTx{}, err
+	}
+	return scopedTx{Tx: tx, owned: true}, nil
+}
+
+// OpenDB opens a Postgres connection pool whose queries are traced as child
+// spans of the context they are run with.
+func OpenDB(dsn string) (*sql.DB, error) {
+	return otelsql.Open("postgres", dsn,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
+}
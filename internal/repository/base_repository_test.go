@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+// deadlineQuerier records the context of every statement it is given.
+type deadlineQuerier struct {
+	contexts []context.Context
+	err      error
+}
+
+func (q *deadlineQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	q.contexts = append(q.contexts, ctx)
+	return nil, q.err
+}
+
+func (q *deadlineQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	panic("not used")
+}
+
+func (q *deadlineQuerier) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	panic("not used")
+}
+
+func TestWithTimeout(t *testing.T) {
+	r := &BaseRepository{timeout: time.Minute}
+
+	t.Run("sets the DB timeout", func(t *testing.T) {
+		ctx, cancel := r.withTimeout(context.Background())
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		if remaining := time.Until(deadline); !ok || remaining <= 50*time.Second || remaining > time.Minute {
+			t.Errorf("deadline in %v, want about a minute", remaining)
+		}
+	})
+
+	t.Run("keeps an earlier caller deadline", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), time.Second)
+		defer cancelParent()
+		want, _ := parent.Deadline()
+
+		ctx, cancel := r.withTimeout(parent)
+		defer cancel()
+
+		if got, _ := ctx.Deadline(); !got.Equal(want) {
+			t.Errorf("deadline = %v, want the caller's %v", got, want)
+		}
+	})
+
+	t.Run("follows caller cancellation", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := r.withTimeout(parent)
+		defer cancel()
+
+		cancelParent()
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			t.Errorf("ctx.Err() = %v after the caller cancelled", ctx.Err())
+		}
+	})
+}
+
+func TestExecWithTimeoutBoundsEachStatement(t *testing.T) {
+	r := &BaseRepository{timeout: 50 * time.Millisecond}
+	q := &deadlineQuerier{}
+
+	// A bulk operation longer than the DB timeout is fine as long as every
+	// statement finishes within it.
+	type marker struct{}
+	ctx := context.WithValue(context.Background(), marker{}, "caller")
+	for range 3 {
+		if err := r.execWithTimeout(ctx, q, "INSERT INTO users VALUES ($1)", "u1"); err != nil {
+			t.Fatalf("execWithTimeout() error = %v", err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+
+	var previous time.Time
+	for i, stmtCtx := range q.contexts {
+		deadline, ok := stmtCtx.Deadline()
+		if !ok || !deadline.After(previous) {
+			t.Errorf("statement %d deadline = %v, want a fresh one after %v", i, deadline, previous)
+		}
+		previous = deadline
+
+		if stmtCtx.Err() == nil {
+			t.Errorf("statement %d context is still live after execWithTimeout returned", i)
+		}
+		if stmtCtx.Value(marker{}) != "caller" {
+			t.Errorf("statement %d lost the caller's context values", i)
+		}
+	}
+}
+
+func TestExecWithTimeoutReturnsError(t *testing.T) {
+	r := &BaseRepository{timeout: time.Second}
+	want := errors.New("duplicate key")
+
+	if err := r.execWithTimeout(context.Background(), &deadlineQuerier{err: want}, "INSERT"); !errors.Is(err, want) {
+		t.Errorf("execWithTimeout() error = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pull_requests_service/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type healthRepository struct {
+	BaseRepository
+}
+
+func NewHealthRepository(db *sql.DB, timeout time.Duration) domain.HealthRepository {
+	return &healthRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+func (r *healthRepository) Ping(ctx context.Context) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.PingContext(ctx)
+}
+
+func (r *healthRepository) GetSchemaVersion(ctx context.Context) (uint, bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var version int64
+	var dirty bool
+	err := r.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "42P01" {
+		// undefined_table: migrations have never been run.
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pull_requests_service/internal/domain"
+	"time"
+)
+
+type importRepository struct {
+	BaseRepository
+}
+
+func NewImportRepository(db *sql.DB, timeout time.Duration) domain.ImportRepository {
+	return &importRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+func (r *importRepository) ApplyImport(ctx context.Context, batch *domain.ImportBatch) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, team := range batch.Teams {
+		err = r.execWithTimeout(ctx, tx, "INSERT INTO team (team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING", team.TeamName)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range team.Members {
+			err = r.execWithTimeout(ctx, tx, `
+                INSERT INTO "user" (user_id, username, is_active, seniority, review_capacity) 
+                VALUES ($1, $2, $3, $4, $5)
+                ON CONFLICT (user_id) 
+                DO UPDATE SET username = EXCLUDED.username, is_active = EXCLUDED.is_active,
+                              seniority = EXCLUDED.seniority, review_capacity = EXCLUDED.review_capacity`,
+				member.UserID, member.Username, member.IsActive, member.Seniority, member.ReviewCapacity,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = r.execWithTimeout(ctx, tx, `
+                INSERT INTO team_member (team_name, user_id) 
+                VALUES ($1, $2) 
+                ON CONFLICT (team_name, user_id) DO NOTHING`,
+				team.TeamName, member.UserID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, pr := range batch.PullRequests {
+		var reviewer1, reviewer2 sql.NullString
+		if len(pr.AssignedReviewers) > 0 {
+			reviewer1 = sql.NullString{String: pr.AssignedReviewers[0], Valid: true}
+		}
+		if len(pr.AssignedReviewers) > 1 {
+			reviewer2 = sql.NullString{String: pr.AssignedReviewers[1], Valid: true}
+		}
+
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO pull_request (pull_request_id, pull_request_name, author_id, status, reviewer_1, reviewer_2,
+                                      created_at, updated_at, last_assigned_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, reviewer1, reviewer2,
+			pr.CreatedAt, pr.UpdatedAt, pr.LastAssignedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, record := range batch.Assignments {
+		err = r.execWithTimeout(ctx, tx, `
+            INSERT INTO pull_request_history (pull_request_id, team_name, user_id, event, reason, actor, created_at)
+            VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)`,
+			record.PullRequestID, record.TeamName, record.UserID, record.Event, record.Reason, record.Actor, record.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pull_requests_service/internal/domain"
+	"time"
+)
+
+type mergePolicyRepository struct {
+	BaseRepository
+}
+
+func NewMergePolicyRepository(db *sql.DB, timeout time.Duration) domain.MergePolicyRepository {
+	return &mergePolicyRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+func (r *mergePolicyRepository) SaveMergePolicy(ctx context.Context, policy *domain.MergePolicy) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).ExecContext(ctx, `
+        INSERT INTO team_merge_policy (team_name, min_approvals, block_on_changes_requested, require_senior_approval, forbid_self_approval)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (team_name)
+        DO UPDATE SET min_approvals = EXCLUDED.min_approvals,
+                      block_on_changes_requested = EXCLUDED.block_on_changes_requested,
+                      require_senior_approval = EXCLUDED.require_senior_approval,
+                      forbid_self_approval = EXCLUDED.forbid_self_approval`,
+		policy.TeamName, policy.MinApprovals, policy.BlockOnChangesRequested, policy.RequireSeniorApproval, policy.ForbidSelfApproval,
+	)
+	return err
+}
+
+func (r *mergePolicyRepository) GetMergePolicy(ctx context.Context, teamName string) (*domain.MergePolicy, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	policy := domain.MergePolicy{TeamName: teamName}
+
+	err := r.conn(ctx).QueryRowContext(ctx, `
+        SELECT min_approvals, block_on_changes_requested, require_senior_approval, forbid_self_approval
+        FROM team_merge_policy WHERE team_name = $1`,
+		teamName,
+	).Scan(&policy.MinApprovals, &policy.BlockOnChangesRequested, &policy.RequireSeniorApproval, &policy.ForbidSelfApproval)
+
+	if err == sql.ErrNoRows {
+		return &policy, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
@@ -1,19 +1,28 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"pull_requests_service/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type prRepository struct {
 	BaseRepository
 }
 
-func NewPRRepository(db *sql.DB) domain.PRRepository {
-	return &prRepository{BaseRepository{db: db}}
+func NewPRRepository(db *sql.DB, timeout time.Duration) domain.PRRepository {
+	return &prRepository{BaseRepository{db: db, timeout: timeout}}
 }
 
-func (r *prRepository) CreatePR(pr *domain.PullRequest) error {
+func (r *prRepository) CreatePR(ctx context.Context, pr *domain.PullRequest) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	var reviewer1, reviewer2 sql.NullString
 	if len(pr.AssignedReviewers) > 0 {
 		reviewer1 = sql.NullString{String: pr.AssignedReviewers[0], Valid: true}
@@ -22,28 +31,57 @@ func (r *prRepository) CreatePR(pr *domain.PullRequest) error {
 		reviewer2 = sql.NullString{String: pr.AssignedReviewers[1], Valid: true}
 	}
 
-	_, err := r.db.Exec(`
-        INSERT INTO pull_request (pull_request_id, pull_request_name, author_id, status, reviewer_1, reviewer_2)
-        VALUES ($1, $2, $3, $4, $5, $6)`,
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var parentID sql.NullString
+	if pr.ParentPullRequestID != "" {
+		parentID = sql.NullString{String: pr.ParentPullRequestID, Valid: true}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO pull_request (pull_request_id, pull_request_name, author_id, status, reviewer_1, reviewer_2,
+                                  parent_pull_request_id, created_at, updated_at, last_assigned_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
 		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, reviewer1, reviewer2,
+		parentID, pr.CreatedAt, pr.UpdatedAt, pr.LastAssignedAt,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	for _, coAuthorID := range pr.CoAuthors {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO pull_request_co_author (pull_request_id, user_id)
+            VALUES ($1, $2)
+            ON CONFLICT (pull_request_id, user_id) DO NOTHING`,
+			pr.PullRequestID, coAuthorID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
-func (r *prRepository) GetPR(prID string) (*domain.PullRequest, error) {
-	var pr domain.PullRequest
-	var reviewer1, reviewer2 sql.NullString
-	var mergedAt sql.NullTime
+const prColumns = `pull_request_id, pull_request_name, author_id, status, reviewer_1, reviewer_2, "mergedAt",
+        parent_pull_request_id, created_at, updated_at, last_assigned_at`
 
-	err := r.db.QueryRow(`
-        SELECT pull_request_id, pull_request_name, author_id, status, reviewer_1, reviewer_2, "mergedAt"
-        FROM pull_request WHERE pull_request_id = $1`,
-		prID,
-	).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &reviewer1, &reviewer2, &mergedAt)
+type rowScanner interface {
+	Scan(dest ...any) error
+}
 
-	if err == sql.ErrNoRows {
-		return nil, domain.ErrPRNotFound
-	}
+func scanPR(row rowScanner) (*domain.PullRequest, error) {
+	var pr domain.PullRequest
+	var reviewer1, reviewer2, parentID sql.NullString
+	var mergedAt, lastAssignedAt sql.NullTime
+
+	err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &reviewer1, &reviewer2, &mergedAt,
+		&parentID, &pr.CreatedAt, &pr.UpdatedAt, &lastAssignedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -54,15 +92,73 @@ func (r *prRepository) GetPR(prID string) (*domain.PullRequest, error) {
 	if reviewer2.Valid {
 		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewer2.String)
 	}
-
 	if mergedAt.Valid {
 		pr.MergedAt = &mergedAt.Time
 	}
+	if lastAssignedAt.Valid {
+		pr.LastAssignedAt = &lastAssignedAt.Time
+	}
+	pr.ParentPullRequestID = parentID.String
 
 	return &pr, nil
 }
 
-func (r *prRepository) UpdatePR(pr *domain.PullRequest) error {
+func (r *prRepository) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	pr, err := scanPR(r.conn(ctx).QueryRowContext(ctx, `
+        SELECT `+prColumns+`
+        FROM pull_request WHERE pull_request_id = $1`,
+		prID,
+	))
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrPRNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pr.CoAuthors, err = r.getCoAuthors(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (r *prRepository) getCoAuthors(ctx context.Context, prID string) ([]string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT user_id FROM pull_request_co_author
+        WHERE pull_request_id = $1
+        ORDER BY user_id`,
+		prID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var coAuthors []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		coAuthors = append(coAuthors, userID)
+	}
+
+	return coAuthors, rows.Err()
+}
+
+func (r *prRepository) UpdatePR(ctx context.Context, pr *domain.PullRequest) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	var reviewer1, reviewer2 sql.NullString
 	if len(pr.AssignedReviewers) > 0 {
 		reviewer1 = sql.NullString{String: pr.AssignedReviewers[0], Valid: true}
@@ -71,18 +167,34 @@ func (r *prRepository) UpdatePR(pr *domain.PullRequest) error {
 		reviewer2 = sql.NullString{String: pr.AssignedReviewers[1], Valid: true}
 	}
 
-	_, err := r.db.Exec(`
+	_, err := r.conn(ctx).ExecContext(ctx, `
         UPDATE pull_request 
-        SET pull_request_name = $1, status = $2, reviewer_1 = $3, reviewer_2 = $4, "mergedAt" = $5
-        WHERE pull_request_id = $6`,
-		pr.PullRequestName, pr.Status, reviewer1, reviewer2, pr.MergedAt, pr.PullRequestID,
+        SET pull_request_name = $1, status = $2, reviewer_1 = $3, reviewer_2 = $4, "mergedAt" = $5,
+            updated_at = $6, last_assigned_at = $7
+        WHERE pull_request_id = $8`,
+		pr.PullRequestName, pr.Status, reviewer1, reviewer2, pr.MergedAt, pr.UpdatedAt, pr.LastAssignedAt, pr.PullRequestID,
 	)
 	return err
 }
 
-func (r *prRepository) GetPRsByReviewer(userID string) ([]*domain.PullRequest, error) {
-	rows, err := r.db.Query(`
-        SELECT pull_request_id, pull_request_name, author_id, status, reviewer_1, reviewer_2, "mergedAt"
+func (r *prRepository) TouchPR(ctx context.Context, prID string, at time.Time) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).ExecContext(ctx, `
+        UPDATE pull_request SET updated_at = GREATEST(updated_at, $2)
+        WHERE pull_request_id = $1`,
+		prID, at,
+	)
+	return err
+}
+
+func (r *prRepository) GetPRsByReviewer(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT `+prColumns+`
         FROM pull_request 
         WHERE reviewer_1 = $1 OR reviewer_2 = $1`,
 		userID,
@@ -94,32 +206,201 @@ func (r *prRepository) GetPRsByReviewer(userID string) ([]*domain.PullRequest, e
 
 	var prs []*domain.PullRequest
 	for rows.Next() {
-		var pr domain.PullRequest
-		var reviewer1, reviewer2 sql.NullString
-		var mergedAt sql.NullTime
-
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &reviewer1, &reviewer2, &mergedAt); err != nil {
+		pr, err := scanPR(rows)
+		if err != nil {
 			return nil, err
 		}
+		prs = append(prs, pr)
+	}
 
-		if reviewer1.Valid {
-			pr.AssignedReviewers = append(pr.AssignedReviewers, reviewer1.String)
-		}
-		if reviewer2.Valid {
-			pr.AssignedReviewers = append(pr.AssignedReviewers, reviewer2.String)
+	return prs, nil
+}
+
+func (r *prRepository) PRExists(ctx context.Context, prID string) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var exists bool
+	err := r.conn(ctx).QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pull_request WHERE pull_request_id = $1)", prID).Scan(&exists)
+	return exists, err
+}
+
+func (r *prRepository) GetOpenReviewCounts(ctx context.Context, teamName string) (map[string]int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT tm.user_id, COUNT(pr.pull_request_id)
+        FROM team_member tm
+        LEFT JOIN pull_request pr
+            ON pr.status = 'OPEN' AND (pr.reviewer_1 = tm.user_id OR pr.reviewer_2 = tm.user_id)
+        WHERE tm.team_name = $1
+        GROUP BY tm.user_id`,
+		teamName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, err
 		}
-		if mergedAt.Valid {
-			pr.MergedAt = &mergedAt.Time
+		counts[userID] = count
+	}
+
+	return counts, rows.Err()
+}
+
+func (r *prRepository) ListPRs(ctx context.Context, filter domain.PRListFilter) ([]*domain.PullRequest, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = "+arg(filter.Status))
+	}
+	if filter.AuthorID != "" {
+		conditions = append(conditions, "author_id = "+arg(filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		p := arg(filter.ReviewerID)
+		conditions = append(conditions, "(reviewer_1 = "+p+" OR reviewer_2 = "+p+")")
+	}
+	if filter.TeamName != "" {
+		conditions = append(conditions, `EXISTS (
+            SELECT 1 FROM team_member tm
+            WHERE tm.user_id = pull_request.author_id AND tm.team_name = `+arg(filter.TeamName)+`)`)
+	}
+	if filter.NameContains != "" {
+		conditions = append(conditions, "pull_request_name ILIKE "+arg("%"+escapeLike(filter.NameContains)+"%"))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.MergedFrom != nil {
+		conditions = append(conditions, `"mergedAt" >= `+arg(*filter.MergedFrom))
+	}
+	if filter.MergedTo != nil {
+		conditions = append(conditions, `"mergedAt" < `+arg(*filter.MergedTo))
+	}
+	if filter.UpdatedFrom != nil {
+		conditions = append(conditions, "updated_at >= "+arg(*filter.UpdatedFrom))
+	}
+	if filter.UpdatedTo != nil {
+		conditions = append(conditions, "updated_at < "+arg(*filter.UpdatedTo))
+	}
+	if filter.LastAssignedFrom != nil {
+		conditions = append(conditions, "last_assigned_at >= "+arg(*filter.LastAssignedFrom))
+	}
+	if filter.LastAssignedTo != nil {
+		conditions = append(conditions, "last_assigned_at < "+arg(*filter.LastAssignedTo))
+	}
+
+	sortColumn := string(filter.SortBy)
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		cursorValue := arg(filter.After.SortValue)
+		if filter.SortBy.IsTime() {
+			cursorValue += "::timestamp"
 		}
+		conditions = append(conditions, fmt.Sprintf("(%s, pull_request_id) %s (%s, %s)",
+			sortColumn, comparison, cursorValue, arg(filter.After.PullRequestID)))
+	}
 
-		prs = append(prs, &pr)
+	query := "SELECT " + prColumns + " FROM pull_request"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, pull_request_id %s LIMIT %s", sortColumn, direction, direction, arg(filter.Limit))
+
+	prs, err := r.queryPRs(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadCoAuthors(ctx, prs); err != nil {
+		return nil, err
 	}
 
 	return prs, nil
 }
 
-func (r *prRepository) PRExists(prID string) (bool, error) {
-	var exists bool
-	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM pull_request WHERE pull_request_id = $1)", prID).Scan(&exists)
-	return exists, err
+// queryPRs scans every row before returning, so the connection is free for
+// the next query when it runs inside a transaction.
+func (r *prRepository) queryPRs(ctx context.Context, query string, args ...any) ([]*domain.PullRequest, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prs []*domain.PullRequest
+	for rows.Next() {
+		pr, err := scanPR(rows)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
+
+// loadCoAuthors fills in the co-authors of a page of PRs with one query.
+func (r *prRepository) loadCoAuthors(ctx context.Context, prs []*domain.PullRequest) error {
+	if len(prs) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.PullRequest, len(prs))
+	ids := make([]string, len(prs))
+	for i, pr := range prs {
+		byID[pr.PullRequestID] = pr
+		ids[i] = pr.PullRequestID
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT pull_request_id, user_id FROM pull_request_co_author
+        WHERE pull_request_id = ANY($1)
+        ORDER BY pull_request_id, user_id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID, userID string
+		if err := rows.Scan(&prID, &userID); err != nil {
+			return err
+		}
+		if pr, ok := byID[prID]; ok {
+			pr.CoAuthors = append(pr.CoAuthors, userID)
+		}
+	}
+
+	return rows.Err()
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
 }
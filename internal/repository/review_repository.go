@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pull_requests_service/internal/domain"
+	"time"
+)
+
+type reviewRepository struct {
+	BaseRepository
+}
+
+func NewReviewRepository(db *sql.DB, timeout time.Duration) domain.ReviewRepository {
+	return &reviewRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+func (r *reviewRepository) SaveReview(ctx context.Context, review *domain.Review) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).ExecContext(ctx, `
+        INSERT INTO pull_request_review (pull_request_id, user_id, state, submitted_at)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (pull_request_id, user_id)
+        DO UPDATE SET state = EXCLUDED.state, submitted_at = EXCLUDED.submitted_at`,
+		review.PullRequestID, review.UserID, review.State, review.SubmittedAt,
+	)
+	return err
+}
+
+func (r *reviewRepository) GetReviews(ctx context.Context, prID string) ([]domain.Review, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT pull_request_id, user_id, state, submitted_at
+        FROM pull_request_review
+        WHERE pull_request_id = $1
+        ORDER BY submitted_at`,
+		prID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []domain.Review
+	for rows.Next() {
+		var review domain.Review
+		if err := rows.Scan(&review.PullRequestID, &review.UserID, &review.State, &review.SubmittedAt); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pull_requests_service/internal/domain"
+	"time"
+)
+
+type reviewerExclusionRepository struct {
+	BaseRepository
+}
+
+func NewReviewerExclusionRepository(db *sql.DB, timeout time.Duration) domain.ReviewerExclusionRepository {
+	return &reviewerExclusionRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+func (r *reviewerExclusionRepository) AddExclusion(ctx context.Context, exclusion domain.ReviewerExclusion) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).ExecContext(ctx, `
+        INSERT INTO team_reviewer_exclusion (team_name, user_id_1, user_id_2)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (team_name, user_id_1, user_id_2) DO NOTHING`,
+		exclusion.TeamName, exclusion.UserID1, exclusion.UserID2,
+	)
+	return err
+}
+
+func (r *reviewerExclusionRepository) RemoveExclusion(ctx context.Context, exclusion domain.ReviewerExclusion) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).ExecContext(ctx, `
+        DELETE FROM team_reviewer_exclusion
+        WHERE team_name = $1 AND user_id_1 = $2 AND user_id_2 = $3`,
+		exclusion.TeamName, exclusion.UserID1, exclusion.UserID2,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrExclusionNotFound
+	}
+
+	return nil
+}
+
+func (r *reviewerExclusionRepository) GetExclusions(ctx context.Context, teamName string) ([]domain.ReviewerExclusion, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT team_name, user_id_1, user_id_2
+        FROM team_reviewer_exclusion
+        WHERE team_name = $1
+        ORDER BY user_id_1, user_id_2`,
+		teamName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exclusions []domain.ReviewerExclusion
+	for rows.Next() {
+		var exclusion domain.ReviewerExclusion
+		if err := rows.Scan(&exclusion.TeamName, &exclusion.UserID1, &exclusion.UserID2); err != nil {
+			return nil, err
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+
+	return exclusions, rows.Err()
+}
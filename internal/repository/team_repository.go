@@ -1,47 +1,51 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"pull_requests_service/internal/domain"
+	"time"
 )
 
 type teamRepository struct {
 	BaseRepository
 }
 
-func NewTeamRepository(db *sql.DB) domain.TeamRepository {
-	return &teamRepository{BaseRepository{db: db}}
+func NewTeamRepository(db *sql.DB, timeout time.Duration) domain.TeamRepository {
+	return &teamRepository{BaseRepository{db: db, timeout: timeout}}
 }
 
-func (r *teamRepository) CreateTeam(team *domain.Team) error {
-	tx, err := r.db.Begin()
+func (r *teamRepository) CreateTeam(ctx context.Context, team *domain.Team) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.beginTx(ctx)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	_, err = tx.Exec("INSERT INTO team (team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING", team.TeamName)
+	_, err = tx.ExecContext(ctx, "INSERT INTO team (team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING", team.TeamName)
 	if err != nil {
 		return err
 	}
 
 	for _, member := range team.Members {
 		var existingTeam string
-		err = tx.QueryRow(`
+		err = tx.QueryRowContext(ctx, `
             SELECT team_name FROM team_member WHERE user_id = $1 AND team_name != $2`,
 			member.UserID, team.TeamName,
 		).Scan(&existingTeam)
 
 		if err == nil {
-			return fmt.Errorf("user %s already belongs to team %s", member.UserID, existingTeam)
+			return &domain.UserInOtherTeamError{UserID: member.UserID, TeamName: existingTeam}
 		}
 
 		if err != sql.ErrNoRows {
 			return err
 		}
 
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
             INSERT INTO team_member (team_name, user_id) 
             VALUES ($1, $2) 
             ON CONFLICT (team_name, user_id) 
@@ -56,9 +60,12 @@ func (r *teamRepository) CreateTeam(team *domain.Team) error {
 	return tx.Commit()
 }
 
-func (r *teamRepository) GetTeam(teamName string) (*domain.Team, error) {
+func (r *teamRepository) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	var exists bool
-	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM team WHERE team_name = $1)", teamName).Scan(&exists)
+	err := r.conn(ctx).QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM team WHERE team_name = $1)", teamName).Scan(&exists)
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +73,8 @@ func (r *teamRepository) GetTeam(teamName string) (*domain.Team, error) {
 		return nil, domain.ErrTeamNotFound
 	}
 
-	rows, err := r.db.Query(`
-        SELECT u.user_id, u.username, u.is_active 
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT u.user_id, u.username, u.is_active, u.seniority, u.review_capacity 
         FROM "user" u 
         JOIN team_member tm ON u.user_id = tm.user_id 
         WHERE tm.team_name = $1`,
@@ -81,7 +88,7 @@ func (r *teamRepository) GetTeam(teamName string) (*domain.Team, error) {
 	var members []domain.TeamMember
 	for rows.Next() {
 		var member domain.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.Seniority, &member.ReviewCapacity); err != nil {
 			return nil, err
 		}
 		members = append(members, member)
@@ -93,8 +100,56 @@ func (r *teamRepository) GetTeam(teamName string) (*domain.Team, error) {
 	}, nil
 }
 
-func (r *teamRepository) TeamExists(teamName string) (bool, error) {
+func (r *teamRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	var exists bool
-	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM team WHERE team_name = $1)", teamName).Scan(&exists)
+	err := r.conn(ctx).QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM team WHERE team_name = $1)", teamName).Scan(&exists)
 	return exists, err
 }
+
+func (r *teamRepository) GetTeamLoad(ctx context.Context, teamName string, weekAgo, monthAgo time.Time) ([]domain.MemberLoad, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	exists, err := r.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT u.user_id, u.username, u.is_active, u.seniority, u.review_capacity,
+               (SELECT COUNT(*) FROM pull_request pr
+                WHERE pr.status = 'OPEN' AND (pr.reviewer_1 = u.user_id OR pr.reviewer_2 = u.user_id)),
+               COUNT(h.id) FILTER (WHERE h.created_at >= $2),
+               COUNT(h.id)
+        FROM "user" u 
+        JOIN team_member tm ON u.user_id = tm.user_id 
+        LEFT JOIN pull_request_history h ON h.user_id = u.user_id AND h.event = $4 AND h.created_at >= $3
+        WHERE tm.team_name = $1
+        GROUP BY u.user_id
+        ORDER BY u.user_id`,
+		teamName, weekAgo, monthAgo, domain.EventAssigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loads []domain.MemberLoad
+	for rows.Next() {
+		var load domain.MemberLoad
+		err := rows.Scan(&load.UserID, &load.Username, &load.IsActive, &load.Seniority, &load.ReviewCapacity,
+			&load.OpenReviews, &load.ReviewsLast7Days, &load.ReviewsLast30Days)
+		if err != nil {
+			return nil, err
+		}
+		loads = append(loads, load)
+	}
+
+	return loads, rows.Err()
+}
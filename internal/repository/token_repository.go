@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pull_requests_service/internal/domain"
+	"time"
+)
+
+type tokenRepository struct {
+	BaseRepository
+}
+
+func NewTokenRepository(db *sql.DB, timeout time.Duration) domain.TokenRepository {
+	return &tokenRepository{BaseRepository{db: db, timeout: timeout}}
+}
+
+func (r *tokenRepository) CreateToken(ctx context.Context, token *domain.APIToken, tokenHash string) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var userID sql.NullString
+	if token.UserID != "" {
+		userID = sql.NullString{String: token.UserID, Valid: true}
+	}
+
+	return r.conn(ctx).QueryRowContext(ctx, `
+        INSERT INTO api_token (name, token_hash, role, user_id, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING token_id`,
+		token.Name, tokenHash, token.Role, userID, token.CreatedAt,
+	).Scan(&token.TokenID)
+}
+
+const tokenColumns = `token_id, name, role, user_id, created_at, revoked_at`
+
+func scanToken(row rowScanner) (*domain.APIToken, error) {
+	var token domain.APIToken
+	var userID sql.NullString
+	var revokedAt sql.NullTime
+
+	if err := row.Scan(&token.TokenID, &token.Name, &token.Role, &userID, &token.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	token.UserID = userID.String
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return &token, nil
+}
+
+func (r *tokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	token, err := scanToken(r.conn(ctx).QueryRowContext(ctx, `
+        SELECT `+tokenColumns+`
+        FROM api_token WHERE token_hash = $1`,
+		tokenHash,
+	))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTokenNotFound
+	}
+	return token, err
+}
+
+func (r *tokenRepository) RevokeToken(ctx context.Context, tokenID int64) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).ExecContext(ctx, `
+        UPDATE api_token SET revoked_at = NOW()
+        WHERE token_id = $1 AND revoked_at IS NULL`,
+		tokenID,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func (r *tokenRepository) ListTokens(ctx context.Context) ([]domain.APIToken, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT `+tokenColumns+`
+        FROM api_token ORDER BY token_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []domain.APIToken
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+
+	return tokens, rows.Err()
+}
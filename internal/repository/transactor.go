@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pull_requests_service/internal/domain"
+)
+
+type txKey struct{}
+
+type transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) domain.Transactor {
+	return &transactor{db: db}
+}
+
+// WithinTx runs fn in a new transaction, or in the one ctx already carries so
+// that units of work can be nested.
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func txFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return tx, ok
+}
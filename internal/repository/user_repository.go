@@ -1,33 +1,42 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"pull_requests_service/internal/domain"
+	"time"
 )
 
 type userRepository struct {
 	BaseRepository
 }
 
-func NewUserRepository(db *sql.DB) domain.UserRepository {
-	return &userRepository{BaseRepository{db: db}}
+func NewUserRepository(db *sql.DB, timeout time.Duration) domain.UserRepository {
+	return &userRepository{BaseRepository{db: db, timeout: timeout}}
 }
 
-func (r *userRepository) CreateOrUpdateUser(user *domain.TeamMember) error {
-	_, err := r.db.Exec(`
-        INSERT INTO "user" (user_id, username, is_active) 
-        VALUES ($1, $2, $3)
+func (r *userRepository) CreateOrUpdateUser(ctx context.Context, user *domain.TeamMember) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).ExecContext(ctx, `
+        INSERT INTO "user" (user_id, username, is_active, seniority, review_capacity) 
+        VALUES ($1, $2, $3, $4, $5)
         ON CONFLICT (user_id) 
-        DO UPDATE SET username = EXCLUDED.username, is_active = EXCLUDED.is_active`,
-		user.UserID, user.Username, user.IsActive,
+        DO UPDATE SET username = EXCLUDED.username, is_active = EXCLUDED.is_active,
+                      seniority = EXCLUDED.seniority, review_capacity = EXCLUDED.review_capacity`,
+		user.UserID, user.Username, user.IsActive, user.Seniority, user.ReviewCapacity,
 	)
 
 	return err
 }
 
-func (r *userRepository) GetUserTeam(userID string) (string, error) {
+func (r *userRepository) GetUserTeam(ctx context.Context, userID string) (string, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	var teamName string
-	err := r.db.QueryRow(`
+	err := r.conn(ctx).QueryRowContext(ctx, `
         SELECT team_name FROM team_member WHERE user_id = $1`,
 		userID,
 	).Scan(&teamName)
@@ -37,8 +46,11 @@ func (r *userRepository) GetUserTeam(userID string) (string, error) {
 	return teamName, err
 }
 
-func (r *userRepository) SetUserActive(userID string, isActive bool) error {
-	_, err := r.db.Exec(`
+func (r *userRepository) SetUserActive(ctx context.Context, userID string, isActive bool) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).ExecContext(ctx, `
         UPDATE "user" SET is_active = $1 WHERE user_id = $2`,
 		isActive, userID,
 	)
@@ -46,21 +58,39 @@ func (r *userRepository) SetUserActive(userID string, isActive bool) error {
 	return err
 }
 
-func (r *userRepository) GetUser(userID string) (*domain.TeamMember, error) {
+func (r *userRepository) RecordActivityChange(ctx context.Context, change domain.ActivityChange) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).ExecContext(ctx, `
+        INSERT INTO user_activity_history (user_id, team_name, is_active, actor, created_at)
+        VALUES ($1, $2, $3, NULLIF($4, ''), $5)`,
+		change.UserID, change.TeamName, change.IsActive, change.Actor, change.CreatedAt,
+	)
+	return err
+}
+
+func (r *userRepository) GetUser(ctx context.Context, userID string) (*domain.TeamMember, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	var user domain.TeamMember
-	err := r.db.QueryRow(`
-        SELECT user_id, username, is_active FROM "user" WHERE user_id = $1`,
+	err := r.conn(ctx).QueryRowContext(ctx, `
+        SELECT user_id, username, is_active, seniority, review_capacity FROM "user" WHERE user_id = $1`,
 		userID,
-	).Scan(&user.UserID, &user.Username, &user.IsActive)
+	).Scan(&user.UserID, &user.Username, &user.IsActive, &user.Seniority, &user.ReviewCapacity)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrUserNotFound
 	}
 	return &user, err
 }
 
-func (r *userRepository) GetActiveTeamMembers(teamName string) ([]domain.TeamMember, error) {
-	rows, err := r.db.Query(`
-        SELECT u.user_id, u.username, u.is_active 
+func (r *userRepository) GetActiveTeamMembers(ctx context.Context, teamName string) ([]domain.TeamMember, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `
+        SELECT u.user_id, u.username, u.is_active, u.seniority, u.review_capacity 
         FROM "user" u 
         JOIN team_member tm ON u.user_id = tm.user_id 
         WHERE tm.team_name = $1 AND u.is_active = true`,
@@ -74,7 +104,7 @@ func (r *userRepository) GetActiveTeamMembers(teamName string) ([]domain.TeamMem
 	var members []domain.TeamMember
 	for rows.Next() {
 		var member domain.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.Seniority, &member.ReviewCapacity); err != nil {
 			return nil, err
 		}
 		members = append(members, member)
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"pull_requests_service/internal/auth"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"strings"
+)
+
+// authenticator resolves a bearer token to the caller. It is implemented by
+// the API token service and by the JWT verifier.
+type authenticator interface {
+	Authenticate(ctx context.Context, token string) (*auth.Identity, error)
+}
+
+type authMiddleware struct {
+	authenticator authenticator
+}
+
+// require authenticates the bearer token of the request and lets it through
+// only when the caller's role allows the given role.
+func (m *authMiddleware) require(role domain.Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := m.authenticator.Authenticate(r.Context(), bearerToken(r))
+		if err != nil {
+			if errors.Is(err, domain.ErrUnauthorized) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="pull_requests_service"`)
+				writeJSONError(w, http.StatusUnauthorized, "UNAUTHORIZED", err.Error())
+				return
+			}
+			slog.ErrorContext(r.Context(), "Authentication failed", "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+			return
+		}
+
+		if !identity.Role.Allows(role) {
+			writeJSONError(w, http.StatusForbidden, "FORBIDDEN", domain.ErrForbidden.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	})
+}
+
+func bearerToken(r *http.Request) string {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(dto.NewErrorResponse(code, message))
+}
@@ -0,0 +1,157 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pull_requests_service/internal/auth"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"strings"
+	"testing"
+)
+
+// fakeAuthenticator maps bearer tokens to callers; any other token is
+// rejected as unauthorized.
+type fakeAuthenticator map[string]*auth.Identity
+
+func (f fakeAuthenticator) Authenticate(ctx context.Context, token string) (*auth.Identity, error) {
+	if identity, ok := f[token]; ok {
+		return identity, nil
+	}
+	return nil, domain.ErrUnauthorized
+}
+
+type failingAuthenticator struct{ err error }
+
+func (f failingAuthenticator) Authenticate(ctx context.Context, token string) (*auth.Identity, error) {
+	return nil, f.err
+}
+
+func TestAuthMiddlewareRequire(t *testing.T) {
+	authz := &authMiddleware{authenticator: fakeAuthenticator{
+		"user-token":  {TokenID: 1, Name: "ci", Role: domain.RoleUser},
+		"admin-token": {TokenID: 2, Name: "ops", Role: domain.RoleAdmin},
+	}}
+
+	tests := []struct {
+		name          string
+		required      domain.Role
+		authorization string
+		wantStatus    int
+		wantCode      string
+	}{
+		{name: "user on a user route", required: domain.RoleUser, authorization: "Bearer user-token", wantStatus: http.StatusOK},
+		{name: "admin on a user route", required: domain.RoleUser, authorization: "Bearer admin-token", wantStatus: http.StatusOK},
+		{name: "admin on an admin route", required: domain.RoleAdmin, authorization: "Bearer admin-token", wantStatus: http.StatusOK},
+		{name: "user on an admin route", required: domain.RoleAdmin, authorization: "Bearer user-token", wantStatus: http.StatusForbidden, wantCode: "FORBIDDEN"},
+		{name: "no Authorization header", required: domain.RoleUser, wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "unknown token", required: domain.RoleUser, authorization: "Bearer nope", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "basic auth scheme", required: domain.RoleUser, authorization: "Basic user-token", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "token without a scheme", required: domain.RoleUser, authorization: "user-token", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { reached = true })
+
+			r := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			authz.require(tt.required, next).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if reached != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("next handler reached = %v with status %d", reached, w.Code)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+
+			var resp dto.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if resp.Error.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", resp.Error.Code, tt.wantCode)
+			}
+			challenge := w.Header().Get("WWW-Authenticate")
+			if wantChallenge := tt.wantStatus == http.StatusUnauthorized; wantChallenge != (challenge != "") {
+				t.Errorf("WWW-Authenticate = %q with status %d", challenge, w.Code)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewarePassesIdentity(t *testing.T) {
+	alice := &auth.Identity{TokenID: 7, Name: "alice", UserID: "u1", Role: domain.RoleUser}
+	authz := &authMiddleware{authenticator: fakeAuthenticator{"alice": alice}}
+
+	var got *auth.Identity
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = auth.FromContext(r.Context())
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+	r.Header.Set("Authorization", "Bearer alice")
+	authz.require(domain.RoleUser, next).ServeHTTP(httptest.NewRecorder(), r)
+
+	if got != alice {
+		t.Errorf("identity in context = %+v, want %+v", got, alice)
+	}
+}
+
+func TestAuthMiddlewareAuthenticatorFailure(t *testing.T) {
+	authz := &authMiddleware{authenticator: failingAuthenticator{err: errors.New("connection refused")}}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("next handler reached after a failed authentication")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+	r.Header.Set("Authorization", "Bearer anything")
+	w := httptest.NewRecorder()
+	authz.require(domain.RoleUser, next).ServeHTTP(w, r)
+
+	// The caller is not to blame, so no 401 and no hint of the cause.
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if w.Header().Get("WWW-Authenticate") != "" {
+		t.Errorf("WWW-Authenticate set on an internal error")
+	}
+	if body := w.Body.String(); !json.Valid([]byte(body)) || strings.Contains(body, "connection refused") {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "Bearer abc", want: "abc"},
+		{header: "bearer abc", want: "abc"},
+		{header: "BEARER  abc ", want: "abc"},
+		{header: "Basic abc", want: ""},
+		{header: "Bearer", want: ""},
+		{header: "abc", want: ""},
+		{header: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			r.Header.Set("Authorization", tt.header)
+		}
+		if got := bearerToken(r); got != tt.want {
+			t.Errorf("bearerToken(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
@@ -1,100 +1,189 @@
 package router
 
 import (
-	"database/sql"
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
-	"net/http/httptest"
+	"pull_requests_service/internal/auth"
 	"pull_requests_service/internal/config"
+	"pull_requests_service/internal/domain"
 	"pull_requests_service/internal/handler"
+	"pull_requests_service/internal/metrics"
 	"pull_requests_service/internal/repository"
 	"pull_requests_service/internal/service"
 	"time"
 )
 
-func SetupRouter(cfg *config.Config) http.Handler {
+// SetupRouter wires the repositories, services and handlers. The service
+// reports itself not ready on /readyz once serving is done.
+func SetupRouter(serving context.Context, cfg *config.Config) (http.Handler, error) {
 
-	db, err := sql.Open("postgres", cfg.GetDBConnectionString())
+	db, err := repository.OpenDB(cfg.GetDBConnectionString())
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return nil, fmt.Errorf("connect to database: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DBTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("ping database: %w", err)
 	}
 
 	db.SetMaxOpenConns(cfg.DBMaxConns)
 	db.SetMaxIdleConns(10)
 	db.SetConnMaxLifetime(time.Hour)
 
-	teamRepo := repository.NewTeamRepository(db)
-	userRepo := repository.NewUserRepository(db)
-	prRepo := repository.NewPRRepository(db)
+	if err := metrics.RegisterDB(db); err != nil {
+		return nil, fmt.Errorf("register database metrics: %w", err)
+	}
+
+	teamRepo := repository.NewTeamRepository(db, cfg.DBTimeout)
+	userRepo := repository.NewUserRepository(db, cfg.DBTimeout)
+	prRepo := repository.NewPRRepository(db, cfg.DBTimeout)
+	reviewRepo := repository.NewReviewRepository(db, cfg.DBTimeout)
+	policyRepo := repository.NewMergePolicyRepository(db, cfg.DBTimeout)
+	exclusionRepo := repository.NewReviewerExclusionRepository(db, cfg.DBTimeout)
+	assignmentRepo := repository.NewAssignmentRepository(db, cfg.DBTimeout)
+	analyticsRepo := repository.NewAnalyticsRepository(db, cfg.DBTimeout)
+	importRepo := repository.NewImportRepository(db, cfg.DBTimeout)
+	backupRepo := repository.NewBackupRepository(db, cfg.DBTimeout)
+	tokenRepo := repository.NewTokenRepository(db, cfg.DBTimeout)
+	healthRepo := repository.NewHealthRepository(db, cfg.DBTimeout)
+	transactor := repository.NewTransactor(db)
+
+	expectedVersion, err := service.LatestMigrationVersion()
+	if err != nil {
+		return nil, fmt.Errorf("read embedded migrations: %w", err)
+	}
 
-	teamService := service.NewTeamService(teamRepo, userRepo)
-	userService := service.NewUserService(userRepo, prRepo)
-	prService := service.NewPRService(prRepo, userRepo)
+	teamService := service.NewTeamService(teamRepo, userRepo, exclusionRepo)
+	userService := service.NewUserService(userRepo, prRepo, transactor)
+	prService := service.NewPRService(prRepo, userRepo, teamRepo, reviewRepo, policyRepo, exclusionRepo, assignmentRepo, transactor)
+	policyService := service.NewMergePolicyService(policyRepo, teamRepo)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, teamRepo)
+	importService := service.NewImportService(importRepo, userRepo, prRepo)
+	backupService := service.NewBackupService(backupRepo)
+	tokenService := service.NewTokenService(tokenRepo, userRepo, cfg.AdminToken)
+	healthService := service.NewHealthService(healthRepo, expectedVersion, serving)
 
 	teamHandler := handler.NewTeamHandler(teamService)
 	userHandler := handler.NewUserHandler(userService)
 	prHandler := handler.NewPRHandler(prService)
+	policyHandler := handler.NewMergePolicyHandler(policyService)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+	importHandler := handler.NewImportHandler(importService)
+	backupHandler := handler.NewBackupHandler(backupService)
+	tokenHandler := handler.NewTokenHandler(tokenService)
+	healthHandler := handler.NewHealthHandler(healthService)
+
+	authz := &authMiddleware{authenticator: tokenService}
+	if cfg.AuthMode == "jwt" {
+		verifier, err := newJWTVerifier(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("set up JWT verification: %w", err)
+		}
+		authz.authenticator = verifier
+	} else if cfg.AdminToken == "" {
+		slog.Warn("ADMIN_TOKEN is not set, only tokens stored in the database are accepted")
+	}
+
+	// Route groups share a rate limit: reads, writes and admin operations.
+	rateLimits, err := cfg.GetRateLimits()
+	if err != nil {
+		return nil, err
+	}
+	// The ip limit runs before authentication so invalid tokens are limited
+	// too; the group limits run after it, per authenticated caller.
+	limitIP := rateLimit(rateLimits, "ip", clientIPKey)
+	limitRead := rateLimit(rateLimits, "read", callerKey)
+	limitWrite := rateLimit(rateLimits, "write", callerKey)
+	limitAdmin := rateLimit(rateLimits, "admin", callerKey)
+
+	admin := func(h http.HandlerFunc) http.Handler { return limitIP(authz.require(domain.RoleAdmin, limitAdmin(h))) }
+	read := func(h http.HandlerFunc) http.Handler { return limitIP(authz.require(domain.RoleUser, limitRead(h))) }
+	write := func(h http.HandlerFunc) http.Handler { return limitIP(authz.require(domain.RoleUser, limitWrite(h))) }
 
 	mux := http.NewServeMux()
 
 	// Team
-	mux.HandleFunc("POST /team/add", teamHandler.AddTeam)
-	mux.HandleFunc("GET /team/get", teamHandler.GetTeam)
+	mux.Handle("POST /team/add", admin(teamHandler.AddTeam))
+	mux.Handle("GET /team/get", read(teamHandler.GetTeam))
+	mux.Handle("GET /team/load", read(teamHandler.GetTeamLoad))
+	mux.Handle("POST /team/setMergePolicy", admin(policyHandler.SetMergePolicy))
+	mux.Handle("GET /team/getMergePolicy", read(policyHandler.GetMergePolicy))
+	mux.Handle("POST /team/addReviewerExclusion", admin(teamHandler.AddReviewerExclusion))
+	mux.Handle("POST /team/removeReviewerExclusion", admin(teamHandler.RemoveReviewerExclusion))
+	mux.Handle("GET /team/getReviewerExclusions", read(teamHandler.GetReviewerExclusions))
 
 	// User
-	mux.HandleFunc("POST /users/setIsActive", userHandler.SetUserActive)
-	mux.HandleFunc("GET /users/getReview", userHandler.GetUserReviews)
+	mux.Handle("POST /users/setIsActive", admin(userHandler.SetUserActive))
+	mux.Handle("GET /users/getReview", read(userHandler.GetUserReviews))
 
 	// PR
-	mux.HandleFunc("POST /pullRequest/create", prHandler.CreatePR)
-	mux.HandleFunc("POST /pullRequest/merge", prHandler.MergePR)
-	mux.HandleFunc("POST /pullRequest/reassign", prHandler.ReassignPR)
+	mux.Handle("POST /pullRequest/create", write(prHandler.CreatePR))
+	mux.Handle("POST /pullRequest/merge", admin(prHandler.MergePR))
+	mux.Handle("POST /pullRequest/reassign", write(prHandler.ReassignPR))
+	mux.Handle("GET /pullRequest/get", read(prHandler.GetPR))
+	mux.Handle("GET /pullRequest/list", read(prHandler.ListPRs))
+	mux.Handle("POST /pullRequest/previewAssignment", read(prHandler.PreviewAssignment))
+	mux.Handle("POST /pullRequest/review", write(prHandler.SubmitReview))
+	mux.Handle("GET /pullRequest/history", read(prHandler.GetAssignmentHistory))
+
+	// Analytics
+	mux.Handle("GET /analytics/reviewLatency", read(analyticsHandler.GetReviewLatency))
+	mux.Handle("GET /analytics/reviewLatency.csv", read(analyticsHandler.GetReviewLatencyCSV))
+
+	// Admin
+	mux.Handle("POST /admin/import", admin(importHandler.Import))
+	mux.Handle("GET /admin/export", admin(backupHandler.Export))
+	mux.Handle("POST /admin/restore", admin(backupHandler.Restore))
+
+	// Auth
+	mux.Handle("POST /auth/createToken", admin(tokenHandler.CreateToken))
+	mux.Handle("POST /auth/revokeToken", admin(tokenHandler.RevokeToken))
+	mux.Handle("GET /auth/listTokens", admin(tokenHandler.ListTokens))
 
 	// Health check
-	mux.HandleFunc("GET /health", healthHandler)
+	mux.HandleFunc("GET /livez", healthHandler.Livez)
+	mux.HandleFunc("GET /readyz", healthHandler.Readyz)
+	mux.HandleFunc("GET /health", healthHandler.Livez)
 
-	handler := applyMiddleware(mux)
+	// Metrics
+	mux.Handle("GET /metrics", metrics.Handler())
 
-	return handler
-}
+	handler := applyMiddleware(mux)
 
-func healthHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte(`{"status": "healthy"}`))
+	return handler, nil
 }
 
 func applyMiddleware(handler http.Handler) http.Handler {
+	handler = metricsMiddleware(handler)
 	handler = loggingMiddleware(handler)
+	handler = tracingMiddleware(handler)
+	handler = requestIDMiddleware(handler)
 	return handler
 }
 
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		recorder := httptest.NewRecorder()
-		next.ServeHTTP(recorder, r)
+func newJWTVerifier(cfg *config.Config) (*auth.JWTVerifier, error) {
+	var keys *auth.KeySet
+	var err error
+	if cfg.JWKSFile != "" {
+		keys, err = auth.NewFileKeySet(cfg.JWKSFile)
+	} else {
+		keys, err = auth.NewURLKeySet(cfg.JWKSURL, cfg.JWKSRefresh)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		for key, values := range recorder.Header() {
-			for _, value := range values {
-				w.Header().Add(key, value)
-			}
-		}
-		w.WriteHeader(recorder.Code)
-		w.Write(recorder.Body.Bytes())
-
-		duration := time.Since(start)
-		log.Printf(
-			"[%s] %s %s - %d %v",
-			r.Method,
-			r.URL.Path,
-			r.RemoteAddr,
-			recorder.Code,
-			duration,
-		)
-	})
+	return &auth.JWTVerifier{
+		Keys:       keys,
+		Issuer:     cfg.JWTIssuer,
+		Audience:   cfg.JWTAudience,
+		UserClaim:  cfg.JWTUserClaim,
+		RolesClaim: cfg.JWTRolesClaim,
+		AdminRole:  cfg.JWTAdminRole,
+	}, nil
 }
@@ -0,0 +1,116 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"pull_requests_service/internal/logging"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		wantKept bool
+	}{
+		{name: "no header", incoming: ""},
+		{name: "uuid", incoming: "3f2b8c1e-9d4a-4b7e-8a1c-2e5f6a7b8c9d", wantKept: true},
+		{name: "trace-style id", incoming: "gw:abc_123.4", wantKept: true},
+		{name: "spaces", incoming: "abc def"},
+		{name: "header injection", incoming: "abc\r\nX-Admin: 1"},
+		{name: "too long", incoming: strings.Repeat("a", maxRequestIDLength+1)},
+		{name: "longest allowed", incoming: strings.Repeat("a", maxRequestIDLength), wantKept: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inContext string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				inContext = logging.RequestID(r.Context())
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/health", nil)
+			if tt.incoming != "" {
+				r.Header.Set(requestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			requestIDMiddleware(next).ServeHTTP(w, r)
+
+			echoed := w.Header().Get(requestIDHeader)
+			if echoed == "" || echoed != inContext {
+				t.Fatalf("response ID %q, context ID %q", echoed, inContext)
+			}
+			if kept := echoed == tt.incoming; kept != tt.wantKept {
+				t.Errorf("request ID %q kept = %v, want %v", tt.incoming, kept, tt.wantKept)
+			}
+			if !tt.wantKept && len(echoed) != 32 {
+				t.Errorf("generated request ID %q is not 16 hex bytes", echoed)
+			}
+		})
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus float64
+		wantBytes  float64
+		wantLevel  string
+	}{
+		{
+			name:       "implicit 200",
+			handler:    func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) },
+			wantStatus: 200, wantBytes: 2, wantLevel: "INFO",
+		},
+		{
+			name:       "nothing written",
+			handler:    func(w http.ResponseWriter, r *http.Request) {},
+			wantStatus: 200, wantBytes: 0, wantLevel: "INFO",
+		},
+		{
+			name:       "client error",
+			handler:    func(w http.ResponseWriter, r *http.Request) { http.Error(w, "no", http.StatusNotFound) },
+			wantStatus: 404, wantBytes: 3, wantLevel: "INFO",
+		},
+		{
+			name: "server error after a second WriteHeader",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+				w.WriteHeader(http.StatusOK)
+			},
+			wantStatus: 502, wantBytes: 0, wantLevel: "ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := logging.New(&buf, "info", "json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer slog.SetDefault(slog.Default())
+			slog.SetDefault(logger)
+
+			r := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", nil)
+			r = r.WithContext(logging.WithRequestID(r.Context(), "req-42"))
+			loggingMiddleware(tt.handler).ServeHTTP(httptest.NewRecorder(), r)
+
+			var record map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("decode log record %q: %v", buf.String(), err)
+			}
+			if record["status"] != tt.wantStatus || record["bytes"] != tt.wantBytes || record["level"] != tt.wantLevel {
+				t.Errorf("record = %v", record)
+			}
+			if record["path"] != "/pullRequest/merge" || record["method"] != "POST" || record["request_id"] != "req-42" {
+				t.Errorf("record = %v", record)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package router
+
+import (
+	"net/http"
+	"pull_requests_service/internal/metrics"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metricsMiddleware counts and times requests per route. It must wrap the
+// mux directly: the mux records the matched pattern on the request it is
+// given, and the route label is read from there.
+func metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+
+		route := "unmatched"
+		if r.Pattern != "" {
+			// Patterns carry the method, which already has its own label.
+			_, path, _ := strings.Cut(r.Pattern, " ")
+			route = path
+		}
+
+		status := strconv.Itoa(sw.status)
+		metrics.HTTPRequests.WithLabelValues(r.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, route, status).Observe(time.Since(start).Seconds())
+	})
+}
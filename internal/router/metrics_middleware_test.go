@@ -0,0 +1,50 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"pull_requests_service/internal/metrics"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsMiddlewareLabels(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /team/get", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("POST /pullRequest/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	handler := metricsMiddleware(mux)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		labels []string
+	}{
+		{name: "matched route", method: http.MethodGet, target: "/team/get?team_name=backend", labels: []string{"GET", "/team/get", "200"}},
+		{name: "error status", method: http.MethodPost, target: "/pullRequest/merge", labels: []string{"POST", "/pullRequest/merge", "409"}},
+		// Unknown paths share one label so scanners cannot blow up the series count.
+		{name: "unknown path", method: http.MethodGet, target: "/wp-admin/setup.php", labels: []string{"GET", "unmatched", "404"}},
+		{name: "wrong method", method: http.MethodDelete, target: "/team/get", labels: []string{"DELETE", "unmatched", "405"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := metrics.HTTPRequests.WithLabelValues(tt.labels...)
+			before := testutil.ToFloat64(counter)
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.target, nil))
+
+			if got := testutil.ToFloat64(counter) - before; got != 1 {
+				t.Errorf("http_requests_total%v grew by %v, want 1", tt.labels, got)
+			}
+		})
+	}
+
+	// Every request above is also timed under the same labels.
+	if got := testutil.CollectAndCount(metrics.HTTPRequestDuration); got < len(tests) {
+		t.Errorf("http_request_duration_seconds has %d series, want at least %d", got, len(tests))
+	}
+}
@@ -0,0 +1,130 @@
+package router
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"pull_requests_service/internal/auth"
+	"pull_requests_service/internal/config"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// bucketIdleTTL is how long an untouched bucket is kept. Buckets refill
+// completely well before that, so dropping them loses no state.
+const bucketIdleTTL = 10 * time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps one token bucket per key, which is the client IP or the
+// authenticated caller depending on where the limiter sits.
+type rateLimiter struct {
+	limit config.RateLimit
+	rate  float64 // tokens per second
+	key   func(r *http.Request) string
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(limit config.RateLimit, key func(r *http.Request) string) *rateLimiter {
+	return &rateLimiter{
+		limit:     limit,
+		rate:      float64(limit.PerMinute) / 60,
+		key:       key,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// take removes a token from the caller's bucket. It reports whether the
+// request is allowed, the tokens left and how long until the next token.
+func (l *rateLimiter) take(key string, now time.Time) (bool, float64, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > bucketIdleTTL {
+		for k, b := range l.buckets {
+			if now.Sub(b.last) > bucketIdleTTL {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(l.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false, b.tokens, l.untilTokens(1 - b.tokens)
+	}
+	b.tokens--
+	return true, b.tokens, 0
+}
+
+func (l *rateLimiter) untilTokens(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+func (l *rateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed, remaining, retryAfter := l.take(l.key(r), time.Now())
+
+		reset := l.untilTokens(float64(l.limit.Burst) - remaining)
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(l.limit.Burst))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(remaining)))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(reset.Seconds()))))
+
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			writeJSONError(w, http.StatusTooManyRequests, "RATE_LIMITED", "rate limit exceeded")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIPKey keys requests by client IP. It is used in front of
+// authentication, where the bearer token is not verified yet and would let
+// a caller get a fresh bucket for every made-up token.
+func clientIPKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// callerKey keys requests by the caller authenticated by authMiddleware.
+func callerKey(r *http.Request) string {
+	identity, ok := auth.FromContext(r.Context())
+	if !ok {
+		return clientIPKey(r)
+	}
+	if identity.TokenID != 0 {
+		return "token:" + strconv.FormatInt(identity.TokenID, 10)
+	}
+	return "actor:" + identity.Actor()
+}
+
+// rateLimit wraps handlers with the limiter of a route group, or leaves them
+// unlimited when the group has no configured limit.
+func rateLimit(limits map[string]config.RateLimit, group string, key func(r *http.Request) string) func(http.Handler) http.Handler {
+	limit, ok := limits[group]
+	if !ok {
+		return func(h http.Handler) http.Handler { return h }
+	}
+	return newRateLimiter(limit, key).middleware
+}
@@ -0,0 +1,173 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"pull_requests_service/internal/auth"
+	"pull_requests_service/internal/config"
+	"pull_requests_service/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTake(t *testing.T) {
+	start := time.Date(2025, 11, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		at            time.Duration
+		key           string
+		wantAllowed   bool
+		wantRemaining float64
+		wantRetry     time.Duration
+	}{
+		{name: "first request gets a full bucket", at: 0, key: "a", wantAllowed: true, wantRemaining: 2},
+		{name: "burst continues", at: 0, key: "a", wantAllowed: true, wantRemaining: 1},
+		{name: "last token of the burst", at: 0, key: "a", wantAllowed: true, wantRemaining: 0},
+		{name: "empty bucket", at: 0, key: "a", wantAllowed: false, wantRemaining: 0, wantRetry: time.Second},
+		{name: "other keys have their own bucket", at: 0, key: "b", wantAllowed: true, wantRemaining: 2},
+		{name: "half a token is not enough", at: 500 * time.Millisecond, key: "a", wantAllowed: false, wantRemaining: 0.5, wantRetry: 500 * time.Millisecond},
+		{name: "refilled one token a second", at: time.Second, key: "a", wantAllowed: true, wantRemaining: 0},
+		{name: "refill stops at the burst", at: time.Minute, key: "a", wantAllowed: true, wantRemaining: 2},
+	}
+
+	limiter := newRateLimiter(config.RateLimit{PerMinute: 60, Burst: 3}, clientIPKey)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, remaining, retry := limiter.take(tt.key, start.Add(tt.at))
+			if allowed != tt.wantAllowed || remaining != tt.wantRemaining || retry != tt.wantRetry {
+				t.Errorf("take() = (%v, %v, %v), want (%v, %v, %v)",
+					allowed, remaining, retry, tt.wantAllowed, tt.wantRemaining, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestRateLimiterSweepsIdleBuckets(t *testing.T) {
+	limiter := newRateLimiter(config.RateLimit{PerMinute: 60, Burst: 3}, clientIPKey)
+	start := limiter.lastSweep
+
+	limiter.take("idle", start)
+	limiter.take("busy", start.Add(bucketIdleTTL))
+	limiter.take("busy", start.Add(bucketIdleTTL+time.Second))
+
+	if _, ok := limiter.buckets["idle"]; ok {
+		t.Errorf("idle bucket was not swept")
+	}
+	if _, ok := limiter.buckets["busy"]; !ok {
+		t.Errorf("busy bucket was swept")
+	}
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		identity   *auth.Identity
+		wantIP     string
+		wantCaller string
+	}{
+		{
+			name:       "API token",
+			remoteAddr: "10.0.0.1:5555",
+			identity:   &auth.Identity{TokenID: 7, Name: "ci"},
+			wantIP:     "ip:10.0.0.1",
+			wantCaller: "token:7",
+		},
+		{
+			name:       "JWT caller",
+			remoteAddr: "10.0.0.1:5555",
+			identity:   &auth.Identity{Name: "u1", UserID: "u1"},
+			wantIP:     "ip:10.0.0.1",
+			wantCaller: "actor:u1",
+		},
+		{
+			name:       "bootstrap admin token",
+			remoteAddr: "10.0.0.1:5555",
+			identity:   &auth.Identity{Name: "admin"},
+			wantIP:     "ip:10.0.0.1",
+			wantCaller: "actor:token:admin",
+		},
+		{
+			name:       "IPv6 client without identity",
+			remoteAddr: "[2001:db8::1]:5555",
+			wantIP:     "ip:2001:db8::1",
+			wantCaller: "ip:2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.identity != nil {
+				r = r.WithContext(auth.WithIdentity(r.Context(), tt.identity))
+			}
+
+			if got := clientIPKey(r); got != tt.wantIP {
+				t.Errorf("clientIPKey() = %q, want %q", got, tt.wantIP)
+			}
+			if got := callerKey(r); got != tt.wantCaller {
+				t.Errorf("callerKey() = %q, want %q", got, tt.wantCaller)
+			}
+		})
+	}
+}
+
+// TestRateLimitAroundAuth runs requests in order through the same chain the
+// router builds: the ip limit, authentication, then the caller limit.
+func TestRateLimitAroundAuth(t *testing.T) {
+	limits := map[string]config.RateLimit{
+		"ip":   {PerMinute: 1, Burst: 4},
+		"read": {PerMinute: 1, Burst: 2},
+	}
+	authz := &authMiddleware{authenticator: fakeAuthenticator{
+		"alice": {TokenID: 1, Name: "alice", Role: domain.RoleUser},
+		"bob":   {TokenID: 2, Name: "bob", Role: domain.RoleUser},
+	}}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := rateLimit(limits, "ip", clientIPKey)(
+		authz.require(domain.RoleUser, rateLimit(limits, "read", callerKey)(ok)))
+
+	steps := []struct {
+		name          string
+		ip            string
+		token         string
+		wantStatus    int
+		wantRemaining string
+	}{
+		{name: "alice first", ip: "10.0.0.1", token: "alice", wantStatus: http.StatusOK, wantRemaining: "1"},
+		{name: "alice second", ip: "10.0.0.1", token: "alice", wantStatus: http.StatusOK, wantRemaining: "0"},
+		{name: "alice over her limit", ip: "10.0.0.1", token: "alice", wantStatus: http.StatusTooManyRequests, wantRemaining: "0"},
+		{name: "alice from another IP is still limited", ip: "10.0.0.2", token: "alice", wantStatus: http.StatusTooManyRequests, wantRemaining: "0"},
+		{name: "bob has his own bucket", ip: "10.0.0.2", token: "bob", wantStatus: http.StatusOK, wantRemaining: "1"},
+		{name: "the shared IP runs out", ip: "10.0.0.1", token: "bob", wantStatus: http.StatusOK, wantRemaining: "0"},
+		{name: "nobody gets past an exhausted IP", ip: "10.0.0.1", token: "bob", wantStatus: http.StatusTooManyRequests, wantRemaining: "0"},
+		{name: "invalid token", ip: "10.0.0.3", token: "made-up-1", wantStatus: http.StatusUnauthorized, wantRemaining: "3"},
+		{name: "another invalid token", ip: "10.0.0.3", token: "made-up-2", wantStatus: http.StatusUnauthorized, wantRemaining: "2"},
+		{name: "no token", ip: "10.0.0.3", token: "", wantStatus: http.StatusUnauthorized, wantRemaining: "1"},
+		{name: "yet another invalid token", ip: "10.0.0.3", token: "made-up-3", wantStatus: http.StatusUnauthorized, wantRemaining: "0"},
+		{name: "made-up tokens share the IP bucket", ip: "10.0.0.3", token: "made-up-4", wantStatus: http.StatusTooManyRequests, wantRemaining: "0"},
+	}
+
+	for _, step := range steps {
+		r := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+		r.RemoteAddr = step.ip + ":5555"
+		if step.token != "" {
+			r.Header.Set("Authorization", "Bearer "+step.token)
+		}
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != step.wantStatus {
+			t.Errorf("%s: status = %d, want %d", step.name, w.Code, step.wantStatus)
+		}
+		if got := w.Header().Get("X-RateLimit-Remaining"); got != step.wantRemaining {
+			t.Errorf("%s: X-RateLimit-Remaining = %q, want %q", step.name, got, step.wantRemaining)
+		}
+		if step.wantStatus == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+			t.Errorf("%s: 429 without Retry-After", step.name)
+		}
+	}
+}
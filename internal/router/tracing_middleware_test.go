@@ -0,0 +1,89 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var handlerSpan trace.SpanContext
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /pullRequest/merge", func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	handler := requestIDMiddleware(tracingMiddleware(mux))
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	r := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", nil)
+	r.Header.Set("traceparent", traceparent)
+	r.Header.Set(requestIDHeader, "req-7")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nowhere", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+
+	merge := spans[0]
+	if merge.Name() != "POST /pullRequest/merge" {
+		t.Errorf("span name = %q", merge.Name())
+	}
+	if merge.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span kind = %v", merge.SpanKind())
+	}
+	if got := merge.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %s, the incoming trace was not continued", got)
+	}
+	if got := merge.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span ID = %s", got)
+	}
+	if handlerSpan.SpanID() != merge.SpanContext().SpanID() {
+		t.Errorf("handler context does not carry the server span")
+	}
+	if merge.Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error for a 500", merge.Status())
+	}
+	attrs := attribute.NewSet(merge.Attributes()...)
+	for key, want := range map[attribute.Key]attribute.Value{
+		"http.route":                attribute.StringValue("POST /pullRequest/merge"),
+		"http.response.status_code": attribute.IntValue(500),
+		"request_id":                attribute.StringValue("req-7"),
+	} {
+		if got, ok := attrs.Value(key); !ok || got != want {
+			t.Errorf("%s = %v, want %v", key, got.Emit(), want.Emit())
+		}
+	}
+
+	unmatched := spans[1]
+	if unmatched.Name() != http.MethodGet || unmatched.Parent().IsValid() {
+		t.Errorf("unmatched request span = %q with parent %v", unmatched.Name(), unmatched.Parent())
+	}
+	unmatchedAttrs := attribute.NewSet(unmatched.Attributes()...)
+	if _, ok := unmatchedAttrs.Value("http.route"); ok {
+		t.Errorf("unmatched request has an http.route attribute")
+	}
+	if unmatched.Status().Code == codes.Error {
+		t.Errorf("a 404 marked the span as failed")
+	}
+}
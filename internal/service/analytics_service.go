@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/tracing"
+	"time"
+)
+
+type AnalyticsService struct {
+	analyticsRepo domain.AnalyticsRepository
+	teamRepo      domain.TeamRepository
+}
+
+func NewAnalyticsService(analyticsRepo domain.AnalyticsRepository, teamRepo domain.TeamRepository) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		teamRepo:      teamRepo,
+	}
+}
+
+func (s *AnalyticsService) GetReviewLatency(ctx context.Context, req dto.ReviewLatencyRequest) ([]domain.ReviewLatencyStats, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetReviewLatency")
+	defer span.End()
+
+	filter := domain.ReviewLatencyFilter{TeamName: req.TeamName}
+
+	ranges := []struct {
+		value  string
+		target **time.Time
+	}{
+		{req.From, &filter.From},
+		{req.To, &filter.To},
+	}
+	for _, r := range ranges {
+		if r.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, r.value)
+		if err != nil {
+			return nil, domain.ErrInvalidFilter
+		}
+		*r.target = &t
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.ErrInvalidFilter
+	}
+
+	if filter.TeamName != "" {
+		exists, err := s.teamRepo.TeamExists(ctx, filter.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
+	}
+
+	return s.analyticsRepo.GetReviewLatency(ctx, filter)
+}
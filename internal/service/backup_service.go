@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/tracing"
+	"slices"
+	"time"
+)
+
+type BackupService struct {
+	backupRepo domain.BackupRepository
+}
+
+func NewBackupService(backupRepo domain.BackupRepository) *BackupService {
+	return &BackupService{backupRepo: backupRepo}
+}
+
+func (s *BackupService) Export(ctx context.Context) (*domain.Archive, error) {
+	ctx, span := tracing.Start(ctx, "BackupService.Export")
+	defer span.End()
+
+	archive, err := s.backupRepo.Export(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Version = domain.ArchiveVersion
+	archive.ExportedAt = time.Now().UTC()
+
+	return archive, nil
+}
+
+func (s *BackupService) Restore(ctx context.Context, archive *domain.Archive) error {
+	ctx, span := tracing.Start(ctx, "BackupService.Restore")
+	defer span.End()
+
+	if err := validateArchive(archive); err != nil {
+		return err
+	}
+
+	return s.backupRepo.Restore(ctx, archive)
+}
+
+// validateArchive checks that the archive is of a supported version and
+// that everything it references is part of the archive itself, so a restore
+// fails before touching the database rather than on a foreign key.
+func validateArchive(archive *domain.Archive) error {
+	if archive.Version != domain.ArchiveVersion {
+		return domain.ErrInvalidArchive
+	}
+
+	users := make(map[string]bool)
+	for _, user := range archive.Users {
+		if user.UserID == "" || users[user.UserID] || !user.Seniority.IsValid() || user.ReviewCapacity < 0 {
+			return domain.ErrInvalidArchive
+		}
+		users[user.UserID] = true
+	}
+
+	teams := make(map[string]bool)
+	members := make(map[string]bool)
+	for _, team := range archive.Teams {
+		if team.TeamName == "" || teams[team.TeamName] {
+			return domain.ErrInvalidArchive
+		}
+		teams[team.TeamName] = true
+		for _, userID := range team.UserIDs {
+			if !users[userID] || members[userID] {
+				return domain.ErrInvalidArchive
+			}
+			members[userID] = true
+		}
+	}
+
+	for _, policy := range archive.MergePolicies {
+		if !teams[policy.TeamName] || policy.MinApprovals < 0 {
+			return domain.ErrInvalidArchive
+		}
+	}
+	for _, exclusion := range archive.ReviewerExclusions {
+		if !teams[exclusion.TeamName] || !users[exclusion.UserID1] || !users[exclusion.UserID2] ||
+			exclusion.UserID1 >= exclusion.UserID2 {
+			return domain.ErrInvalidArchive
+		}
+	}
+	for _, cursor := range archive.RotationCursors {
+		if !teams[cursor.TeamName] {
+			return domain.ErrInvalidArchive
+		}
+	}
+
+	prs := make(map[string]bool)
+	for _, pr := range archive.PullRequests {
+		if pr.PullRequestID == "" || prs[pr.PullRequestID] || !users[pr.AuthorID] {
+			return domain.ErrInvalidArchive
+		}
+		if pr.Status != domain.PRStatusOpen && pr.Status != domain.PRStatusMerged {
+			return domain.ErrInvalidArchive
+		}
+		if len(pr.AssignedReviewers) > maxReviewers {
+			return domain.ErrInvalidArchive
+		}
+		for _, userID := range slices.Concat(pr.AssignedReviewers, pr.CoAuthors) {
+			if !users[userID] {
+				return domain.ErrInvalidArchive
+			}
+		}
+		prs[pr.PullRequestID] = true
+	}
+	for _, pr := range archive.PullRequests {
+		if pr.ParentPullRequestID != "" && !prs[pr.ParentPullRequestID] {
+			return domain.ErrInvalidArchive
+		}
+	}
+
+	for _, review := range archive.Reviews {
+		if !prs[review.PullRequestID] || !users[review.UserID] || !review.State.IsValid() {
+			return domain.ErrInvalidArchive
+		}
+	}
+	for _, record := range archive.History {
+		if !prs[record.PullRequestID] {
+			return domain.ErrInvalidArchive
+		}
+	}
+	for _, change := range archive.ActivityHistory {
+		if !users[change.UserID] {
+			return domain.ErrInvalidArchive
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"pull_requests_service/internal/domain"
+	"testing"
+	"time"
+)
+
+// testArchive returns a small archive that passes validateArchive.
+func testArchive() *domain.Archive {
+	now := time.Date(2025, 11, 3, 10, 0, 0, 0, time.UTC)
+	return &domain.Archive{
+		Version:    domain.ArchiveVersion,
+		ExportedAt: now,
+		Users: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, Seniority: domain.SenioritySenior},
+			{UserID: "u2", Username: "Bob", IsActive: true, Seniority: domain.SeniorityMiddle},
+			{UserID: "u3", Username: "Carol", IsActive: false, Seniority: domain.SeniorityJunior, ReviewCapacity: 2},
+		},
+		Teams:              []domain.TeamMembership{{TeamName: "backend", UserIDs: []string{"u1", "u2", "u3"}}},
+		MergePolicies:      []domain.MergePolicy{{TeamName: "backend", MinApprovals: 1}},
+		ReviewerExclusions: []domain.ReviewerExclusion{domain.NewReviewerExclusion("backend", "u3", "u2")},
+		RotationCursors:    []domain.RotationCursor{{TeamName: "backend", UserID: "u2"}},
+		PullRequests: []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "u3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1", "u2"}},
+			{PullRequestID: "pr-2", AuthorID: "u2", Status: domain.PRStatusMerged, CoAuthors: []string{"u3"}, ParentPullRequestID: "pr-1"},
+		},
+		Reviews: []domain.Review{{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateApproved, SubmittedAt: now}},
+		History: []domain.AssignmentRecord{
+			{PullRequestID: "pr-1", TeamName: "backend", UserID: "u1", Event: domain.EventAssigned, Reason: domain.ReasonRotation},
+		},
+		ActivityHistory: []domain.ActivityChange{
+			{UserID: "u3", TeamName: "backend", IsActive: false, Actor: "token:admin", CreatedAt: now},
+		},
+	}
+}
+
+func TestValidateArchive(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(a *domain.Archive)
+		valid  bool
+	}{
+		{name: "valid", mutate: func(a *domain.Archive) {}, valid: true},
+		{name: "empty archive", mutate: func(a *domain.Archive) { *a = domain.Archive{Version: domain.ArchiveVersion} }, valid: true},
+		{name: "other version", mutate: func(a *domain.Archive) { a.Version = domain.ArchiveVersion + 1 }},
+		{name: "user without id", mutate: func(a *domain.Archive) { a.Users[0].UserID = "" }},
+		{name: "duplicate user", mutate: func(a *domain.Archive) { a.Users[1].UserID = "u1" }},
+		{name: "invalid seniority", mutate: func(a *domain.Archive) { a.Users[0].Seniority = "LEAD" }},
+		{name: "negative capacity", mutate: func(a *domain.Archive) { a.Users[2].ReviewCapacity = -1 }},
+		{name: "duplicate team", mutate: func(a *domain.Archive) {
+			a.Teams = append(a.Teams, domain.TeamMembership{TeamName: "backend"})
+		}},
+		{name: "member that is not a user", mutate: func(a *domain.Archive) { a.Teams[0].UserIDs[0] = "u9" }},
+		{name: "user in two teams", mutate: func(a *domain.Archive) {
+			a.Teams = append(a.Teams, domain.TeamMembership{TeamName: "frontend", UserIDs: []string{"u1"}})
+		}},
+		{name: "policy of a missing team", mutate: func(a *domain.Archive) { a.MergePolicies[0].TeamName = "frontend" }},
+		{name: "negative min approvals", mutate: func(a *domain.Archive) { a.MergePolicies[0].MinApprovals = -1 }},
+		{name: "exclusion pair out of order", mutate: func(a *domain.Archive) {
+			a.ReviewerExclusions[0].UserID1, a.ReviewerExclusions[0].UserID2 = "u3", "u2"
+		}},
+		{name: "exclusion of a missing user", mutate: func(a *domain.Archive) { a.ReviewerExclusions[0].UserID2 = "u9" }},
+		{name: "cursor of a missing team", mutate: func(a *domain.Archive) { a.RotationCursors[0].TeamName = "frontend" }},
+		{name: "duplicate PR", mutate: func(a *domain.Archive) { a.PullRequests[1].PullRequestID = "pr-1" }},
+		{name: "PR by a missing author", mutate: func(a *domain.Archive) { a.PullRequests[0].AuthorID = "u9" }},
+		{name: "PR with an unknown status", mutate: func(a *domain.Archive) { a.PullRequests[0].Status = "CLOSED" }},
+		{name: "PR with too many reviewers", mutate: func(a *domain.Archive) {
+			a.PullRequests[0].AssignedReviewers = []string{"u1", "u2", "u3"}
+		}},
+		{name: "PR reviewed by a missing user", mutate: func(a *domain.Archive) { a.PullRequests[0].AssignedReviewers[1] = "u9" }},
+		{name: "PR co-authored by a missing user", mutate: func(a *domain.Archive) { a.PullRequests[1].CoAuthors[0] = "u9" }},
+		{name: "parent PR not in the archive", mutate: func(a *domain.Archive) { a.PullRequests[1].ParentPullRequestID = "pr-9" }},
+		{name: "review of a missing PR", mutate: func(a *domain.Archive) { a.Reviews[0].PullRequestID = "pr-9" }},
+		{name: "review with an unknown state", mutate: func(a *domain.Archive) { a.Reviews[0].State = "COMMENTED" }},
+		{name: "history of a missing PR", mutate: func(a *domain.Archive) { a.History[0].PullRequestID = "pr-9" }},
+		{name: "activity change of a missing user", mutate: func(a *domain.Archive) { a.ActivityHistory[0].UserID = "u9" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := testArchive()
+			tt.mutate(archive)
+
+			err := validateArchive(archive)
+			if tt.valid && err != nil {
+				t.Errorf("validateArchive() error = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, domain.ErrInvalidArchive) {
+				t.Errorf("validateArchive() error = %v, want ErrInvalidArchive", err)
+			}
+		})
+	}
+}
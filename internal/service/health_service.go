@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"pull_requests_service/internal/domain"
+	"time"
+)
+
+// probeTimeout keeps a readiness check well below the probe timeouts of
+// orchestrators even when the database hangs.
+const probeTimeout = 2 * time.Second
+
+type HealthService struct {
+	healthRepo      domain.HealthRepository
+	expectedVersion uint
+	serving         context.Context
+}
+
+// NewHealthService creates the health service. The service reports itself
+// not ready as soon as serving is done, which happens when graceful shutdown
+// begins.
+func NewHealthService(healthRepo domain.HealthRepository, expectedVersion uint, serving context.Context) *HealthService {
+	return &HealthService{
+		healthRepo:      healthRepo,
+		expectedVersion: expectedVersion,
+		serving:         serving,
+	}
+}
+
+func (s *HealthService) CheckReadiness(ctx context.Context) *domain.Readiness {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	components := []domain.ComponentCheck{
+		s.checkServing(),
+		s.checkDatabase(ctx),
+		s.checkMigrations(ctx),
+	}
+
+	readiness := &domain.Readiness{Ready: true, Components: components}
+	for _, component := range components {
+		if !component.Healthy {
+			readiness.Ready = false
+		}
+	}
+	return readiness
+}
+
+func (s *HealthService) checkServing() domain.ComponentCheck {
+	check := domain.ComponentCheck{Name: "server", Healthy: s.serving.Err() == nil}
+	if !check.Healthy {
+		check.Message = "shutting down"
+	}
+	return check
+}
+
+func (s *HealthService) checkDatabase(ctx context.Context) domain.ComponentCheck {
+	start := time.Now()
+	err := s.healthRepo.Ping(ctx)
+
+	check := domain.ComponentCheck{Name: "database", Healthy: err == nil, Latency: time.Since(start)}
+	if err != nil {
+		// The probe needs no token, so driver errors naming hosts and users
+		// only go to the log.
+		slog.WarnContext(ctx, "Readiness: database is unreachable", "error", err)
+		check.Message = "database is unreachable"
+	}
+	return check
+}
+
+func (s *HealthService) checkMigrations(ctx context.Context) domain.ComponentCheck {
+	check := domain.ComponentCheck{Name: "migrations"}
+
+	version, dirty, err := s.healthRepo.GetSchemaVersion(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Readiness: could not read the schema version", "error", err)
+		check.Message = "could not read the schema version"
+		return check
+	}
+
+	check.Details = map[string]any{
+		"version":  version,
+		"expected": s.expectedVersion,
+		"dirty":    dirty,
+	}
+	switch {
+	case dirty:
+		check.Message = fmt.Sprintf("migration %d failed half-way", version)
+	case version != s.expectedVersion:
+		check.Message = fmt.Sprintf("schema version %d does not match expected %d", version, s.expectedVersion)
+	default:
+		check.Healthy = true
+	}
+	return check
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubHealth struct {
+	pingErr    error
+	version    uint
+	dirty      bool
+	versionErr error
+
+	pingDeadline time.Time
+}
+
+func (s *stubHealth) Ping(ctx context.Context) error {
+	s.pingDeadline, _ = ctx.Deadline()
+	return s.pingErr
+}
+
+func (s *stubHealth) GetSchemaVersion(ctx context.Context) (uint, bool, error) {
+	return s.version, s.dirty, s.versionErr
+}
+
+func TestCheckReadiness(t *testing.T) {
+	const expected = 15
+	driverErr := errors.New(`dial tcp 10.0.3.7:5432: password authentication failed for user "pr_service"`)
+
+	tests := []struct {
+		name        string
+		repo        stubHealth
+		shutdown    bool
+		wantReady   bool
+		wantDown    string
+		wantMessage string
+	}{
+		{name: "all good", repo: stubHealth{version: expected}, wantReady: true},
+		{name: "database down", repo: stubHealth{pingErr: driverErr, version: expected}, wantDown: "database", wantMessage: "database is unreachable"},
+		{name: "version unreadable", repo: stubHealth{versionErr: driverErr}, wantDown: "migrations", wantMessage: "could not read the schema version"},
+		{name: "no migration applied", repo: stubHealth{version: 0}, wantDown: "migrations", wantMessage: "schema version 0 does not match expected 15"},
+		{name: "schema behind", repo: stubHealth{version: expected - 1}, wantDown: "migrations", wantMessage: "schema version 14 does not match expected 15"},
+		// A newer schema may have dropped columns this binary still reads.
+		{name: "schema ahead", repo: stubHealth{version: expected + 1}, wantDown: "migrations", wantMessage: "schema version 16 does not match expected 15"},
+		{name: "dirty migration", repo: stubHealth{version: expected, dirty: true}, wantDown: "migrations", wantMessage: "migration 15 failed half-way"},
+		{name: "shutting down", repo: stubHealth{version: expected}, shutdown: true, wantDown: "server", wantMessage: "shutting down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serving, stop := context.WithCancel(context.Background())
+			defer stop()
+			if tt.shutdown {
+				stop()
+			}
+
+			repo := tt.repo
+			readiness := NewHealthService(&repo, expected, serving).CheckReadiness(context.Background())
+
+			if readiness.Ready != tt.wantReady {
+				t.Errorf("Ready = %v, want %v", readiness.Ready, tt.wantReady)
+			}
+			for _, component := range readiness.Components {
+				wantHealthy := component.Name != tt.wantDown
+				if component.Healthy != wantHealthy {
+					t.Errorf("%s healthy = %v, want %v", component.Name, component.Healthy, wantHealthy)
+				}
+				if component.Name == tt.wantDown && component.Message != tt.wantMessage {
+					t.Errorf("%s message = %q, want %q", component.Name, component.Message, tt.wantMessage)
+				}
+				if strings.Contains(component.Message, "10.0.3.7") {
+					t.Errorf("%s message leaks the driver error: %q", component.Name, component.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckReadinessBoundsTheProbe(t *testing.T) {
+	repo := &stubHealth{version: 1}
+	s := NewHealthService(repo, 1, context.Background())
+
+	s.CheckReadiness(context.Background())
+
+	if repo.pingDeadline.IsZero() || time.Until(repo.pingDeadline) > probeTimeout {
+		t.Errorf("ping deadline = %v, want at most %v away", repo.pingDeadline, probeTimeout)
+	}
+}
+
+func TestReadinessComponents(t *testing.T) {
+	readiness := NewHealthService(&stubHealth{version: 3}, 3, context.Background()).CheckReadiness(context.Background())
+
+	var names []string
+	for _, component := range readiness.Components {
+		names = append(names, component.Name)
+	}
+	if got := strings.Join(names, ","); got != "server,database,migrations" {
+		t.Errorf("components = %s", got)
+	}
+
+	migrations := readiness.Components[2]
+	want := map[string]any{"version": uint(3), "expected": uint(3), "dirty": false}
+	for key, value := range want {
+		if migrations.Details[key] != value {
+			t.Errorf("migrations %s = %v, want %v", key, migrations.Details[key], value)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImportFormatJSON = "json"
+	ImportFormatCSV  = "csv"
+)
+
+const (
+	recordTeam        = "team"
+	recordMember      = "member"
+	recordPullRequest = "pull_request"
+)
+
+// importRow is a single team, member or PR taken from the import file,
+// remembering where it came from for the error report.
+type importRow struct {
+	location    string
+	teamName    string
+	member      *dto.ImportMember
+	pullRequest *dto.ImportPullRequest
+}
+
+var csvColumns = []string{
+	"record_type", "team_name", "user_id", "username", "is_active", "seniority", "review_capacity",
+	"pull_request_id", "pull_request_name", "author_id", "assigned_reviewers",
+}
+
+func parseImport(format string, body io.Reader) ([]importRow, []domain.ImportError, error) {
+	switch format {
+	case ImportFormatJSON:
+		rows, errs := parseImportJSON(body)
+		return rows, errs, nil
+	case ImportFormatCSV:
+		rows, errs := parseImportCSV(body)
+		return rows, errs, nil
+	default:
+		return nil, nil, domain.ErrImportFormat
+	}
+}
+
+func parseImportJSON(body io.Reader) ([]importRow, []domain.ImportError) {
+	var req dto.ImportRequest
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return nil, []domain.ImportError{{Location: "body", Message: err.Error()}}
+	}
+
+	var rows []importRow
+	for i, team := range req.Teams {
+		rows = append(rows, importRow{
+			location: fmt.Sprintf("teams[%d]", i),
+			teamName: team.TeamName,
+		})
+		for j := range team.Members {
+			rows = append(rows, importRow{
+				location: fmt.Sprintf("teams[%d].members[%d]", i, j),
+				teamName: team.TeamName,
+				member:   &team.Members[j],
+			})
+		}
+	}
+	for i := range req.PullRequests {
+		rows = append(rows, importRow{
+			location:    fmt.Sprintf("pull_requests[%d]", i),
+			pullRequest: &req.PullRequests[i],
+		})
+	}
+
+	return rows, nil
+}
+
+// parseImportCSV reads a single CSV file whose record_type column says
+// whether a line describes a team, a member or an open PR. Reviewers are
+// separated by ";" in assigned_reviewers.
+func parseImportCSV(body io.Reader) ([]importRow, []domain.ImportError) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, []domain.ImportError{{Location: "row 1", Message: "missing header: " + err.Error()}}
+	}
+
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.TrimSpace(column)] = i
+	}
+	var unknown []string
+	for column := range index {
+		if !contains(csvColumns, column) {
+			unknown = append(unknown, column)
+		}
+	}
+	if _, ok := index["record_type"]; !ok {
+		return nil, []domain.ImportError{{Location: "row 1", Message: "record_type column is required"}}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return nil, []domain.ImportError{{Location: "row 1", Message: "unknown columns: " + strings.Join(unknown, ", ")}}
+	}
+
+	var rows []importRow
+	var errs []domain.ImportError
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		location := fmt.Sprintf("row %d", line)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return rows, append(errs, domain.ImportError{Location: fmt.Sprintf("row %d", parseErr.Line), Message: parseErr.Err.Error()})
+			}
+			return rows, append(errs, domain.ImportError{Location: location, Message: err.Error()})
+		}
+
+		field := func(column string) string {
+			i, ok := index[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row := importRow{location: location, teamName: field("team_name")}
+		switch field("record_type") {
+		case recordTeam:
+		case recordMember:
+			member := &dto.ImportMember{
+				UserID:    field("user_id"),
+				Username:  field("username"),
+				Seniority: field("seniority"),
+			}
+			if value := field("is_active"); value != "" {
+				isActive, err := strconv.ParseBool(value)
+				if err != nil {
+					errs = append(errs, domain.ImportError{Location: location, Message: "is_active must be true or false"})
+					continue
+				}
+				member.IsActive = &isActive
+			}
+			if value := field("review_capacity"); value != "" {
+				capacity, err := strconv.Atoi(value)
+				if err != nil {
+					errs = append(errs, domain.ImportError{Location: location, Message: "review_capacity must be an integer"})
+					continue
+				}
+				member.ReviewCapacity = capacity
+			}
+			row.member = member
+		case recordPullRequest:
+			pr := &dto.ImportPullRequest{
+				PullRequestID:   field("pull_request_id"),
+				PullRequestName: field("pull_request_name"),
+				AuthorID:        field("author_id"),
+			}
+			for _, reviewer := range strings.Split(field("assigned_reviewers"), ";") {
+				if reviewer = strings.TrimSpace(reviewer); reviewer != "" {
+					pr.AssignedReviewers = append(pr.AssignedReviewers, reviewer)
+				}
+			}
+			row.pullRequest = pr
+		default:
+			errs = append(errs, domain.ImportError{Location: location, Message: "record_type must be team, member or pull_request"})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, errs
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"pull_requests_service/internal/domain"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// describeRow renders a parsed row compactly so whole results can be
+// compared as string slices.
+func describeRow(row importRow) string {
+	switch {
+	case row.member != nil:
+		active := "-"
+		if row.member.IsActive != nil {
+			active = fmt.Sprint(*row.member.IsActive)
+		}
+		return fmt.Sprintf("%s member %s/%s name=%s seniority=%s active=%s capacity=%d", row.location, row.teamName,
+			row.member.UserID, row.member.Username, row.member.Seniority, active, row.member.ReviewCapacity)
+	case row.pullRequest != nil:
+		return fmt.Sprintf("%s pr %s %q by %s reviewers=%v", row.location, row.pullRequest.PullRequestID,
+			row.pullRequest.PullRequestName, row.pullRequest.AuthorID, row.pullRequest.AssignedReviewers)
+	default:
+		return fmt.Sprintf("%s team %s", row.location, row.teamName)
+	}
+}
+
+func TestParseImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		body     string
+		wantRows []string
+		wantErrs []string
+		wantErr  error
+	}{
+		{
+			name:   "json",
+			format: ImportFormatJSON,
+			body: `{
+				"teams": [{"team_name": "backend", "members": [
+					{"user_id": "u1", "username": "Alice", "is_active": false, "seniority": "SENIOR", "review_capacity": 3},
+					{"user_id": "u2", "username": "Bob"}
+				]}],
+				"pull_requests": [{"pull_request_id": "pr-1", "pull_request_name": "Fix", "author_id": "u2", "assigned_reviewers": ["u1"]}]
+			}`,
+			wantRows: []string{
+				"teams[0] team backend",
+				"teams[0].members[0] member backend/u1 name=Alice seniority=SENIOR active=false capacity=3",
+				"teams[0].members[1] member backend/u2 name=Bob seniority= active=- capacity=0",
+				`pull_requests[0] pr pr-1 "Fix" by u2 reviewers=[u1]`,
+			},
+		},
+		{
+			name:     "json with an unknown field",
+			format:   ImportFormatJSON,
+			body:     `{"teams": [], "users": []}`,
+			wantErrs: []string{`body: json: unknown field "users"`},
+		},
+		{
+			name:   "csv",
+			format: ImportFormatCSV,
+			body: "record_type,team_name,user_id,username,is_active,seniority,review_capacity,pull_request_id,pull_request_name,author_id,assigned_reviewers\n" +
+				"team,backend,,,,,,,,,\n" +
+				"member,backend,u1,Alice,false,SENIOR,3,,,,\n" +
+				"member, backend ,u2,Bob,,,,,,,\n" +
+				"pull_request,,,,,,,pr-1,Fix,u2,u1; u3;\n",
+			wantRows: []string{
+				"row 2 team backend",
+				"row 3 member backend/u1 name=Alice seniority=SENIOR active=false capacity=3",
+				"row 4 member backend/u2 name=Bob seniority= active=- capacity=0",
+				`row 5 pr pr-1 "Fix" by u2 reviewers=[u1 u3]`,
+			},
+		},
+		{
+			name:   "csv with a subset of columns",
+			format: ImportFormatCSV,
+			body:   "record_type,team_name,user_id,username\nmember,backend,u1,Alice\n",
+			wantRows: []string{
+				"row 2 member backend/u1 name=Alice seniority= active=- capacity=0",
+			},
+		},
+		{
+			name:   "csv rows with bad values are reported and skipped",
+			format: ImportFormatCSV,
+			body: "record_type,team_name,user_id,is_active,review_capacity\n" +
+				"member,backend,u1,maybe,\n" +
+				"member,backend,u2,,many\n" +
+				"user,backend,u3,,\n" +
+				"member,backend,u4,true,1\n",
+			wantRows: []string{"row 5 member backend/u4 name= seniority= active=true capacity=1"},
+			wantErrs: []string{
+				"row 2: is_active must be true or false",
+				"row 3: review_capacity must be an integer",
+				"row 4: record_type must be team, member or pull_request",
+			},
+		},
+		{
+			name:     "csv without record_type",
+			format:   ImportFormatCSV,
+			body:     "team_name,user_id\nbackend,u1\n",
+			wantErrs: []string{"row 1: record_type column is required"},
+		},
+		{
+			name:     "csv with unknown columns",
+			format:   ImportFormatCSV,
+			body:     "record_type,email,team_name,age\n",
+			wantErrs: []string{"row 1: unknown columns: age, email"},
+		},
+		{
+			name:     "empty csv",
+			format:   ImportFormatCSV,
+			body:     "",
+			wantErrs: []string{"row 1: missing header: EOF"},
+		},
+		{
+			name:    "unknown format",
+			format:  "xml",
+			body:    "<teams/>",
+			wantErr: domain.ErrImportFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, errs, err := parseImport(tt.format, strings.NewReader(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseImport() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var gotRows, gotErrs []string
+			for _, row := range rows {
+				gotRows = append(gotRows, describeRow(row))
+			}
+			for _, e := range errs {
+				gotErrs = append(gotErrs, e.Location+": "+e.Message)
+			}
+
+			if !slices.Equal(gotRows, tt.wantRows) {
+				t.Errorf("rows:\n got %q\nwant %q", gotRows, tt.wantRows)
+			}
+			if !slices.Equal(gotErrs, tt.wantErrs) {
+				t.Errorf("errors:\n got %q\nwant %q", gotErrs, tt.wantErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"pull_requests_service/internal/auth"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/tracing"
+	"time"
+)
+
+type ImportService struct {
+	importRepo domain.ImportRepository
+	userRepo   domain.UserRepository
+	prRepo     domain.PRRepository
+}
+
+func NewImportService(importRepo domain.ImportRepository, userRepo domain.UserRepository, prRepo domain.PRRepository) *ImportService {
+	return &ImportService{
+		importRepo: importRepo,
+		userRepo:   userRepo,
+		prRepo:     prRepo,
+	}
+}
+
+// Import validates the whole file before touching the database and applies
+// it in one transaction only when every row is valid. With dryRun set the
+// report is produced but nothing is written. ErrInvalidImport is returned
+// together with the report when any row is rejected.
+func (s *ImportService) Import(ctx context.Context, format string, body io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	ctx, span := tracing.Start(ctx, "ImportService.Import")
+	defer span.End()
+
+	rows, parseErrs, err := parseImport(format, body)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, validationErrs, err := s.buildImportBatch(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{
+		Teams:        len(batch.Teams),
+		PullRequests: len(batch.PullRequests),
+		Errors:       append(parseErrs, validationErrs...),
+	}
+	for _, team := range batch.Teams {
+		report.Users += len(team.Members)
+	}
+
+	if len(report.Errors) > 0 {
+		return report, domain.ErrInvalidImport
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	if err := s.importRepo.ApplyImport(ctx, batch); err != nil {
+		return nil, err
+	}
+	report.Applied = true
+
+	return report, nil
+}
+
+func (s *ImportService) buildImportBatch(ctx context.Context, rows []importRow) (*domain.ImportBatch, []domain.ImportError, error) {
+	actor := auth.ActorFromContext(ctx)
+	batch := &domain.ImportBatch{}
+	var errs []domain.ImportError
+	reject := func(row importRow, format string, args ...any) {
+		errs = append(errs, domain.ImportError{Location: row.location, Message: fmt.Sprintf(format, args...)})
+	}
+
+	teamIndex := make(map[string]int)
+	memberTeams := make(map[string]string)
+	memberLocations := make(map[string]string)
+
+	for _, row := range rows {
+		if row.pullRequest != nil {
+			continue
+		}
+		if row.teamName == "" {
+			reject(row, "team_name is required")
+			continue
+		}
+		if _, ok := teamIndex[row.teamName]; !ok {
+			teamIndex[row.teamName] = len(batch.Teams)
+			batch.Teams = append(batch.Teams, domain.Team{TeamName: row.teamName})
+		}
+		if row.member == nil {
+			continue
+		}
+
+		member := row.member
+		if member.UserID == "" {
+			reject(row, "user_id is required")
+			continue
+		}
+		if first, ok := memberLocations[member.UserID]; ok {
+			reject(row, "user %s is already listed at %s", member.UserID, first)
+			continue
+		}
+
+		seniority, err := memberSeniority(ctx, s.userRepo, member.UserID, member.Seniority)
+		if errors.Is(err, domain.ErrInvalidSeniority) {
+			reject(row, "seniority must be JUNIOR, MIDDLE or SENIOR")
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if member.ReviewCapacity < 0 {
+			reject(row, "review_capacity must not be negative")
+			continue
+		}
+
+		currentTeam, err := s.userRepo.GetUserTeam(ctx, member.UserID)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, nil, err
+		}
+		if err == nil && currentTeam != row.teamName {
+			reject(row, "user %s already belongs to team %s", member.UserID, currentTeam)
+			continue
+		}
+
+		isActive := true
+		if member.IsActive != nil {
+			isActive = *member.IsActive
+		}
+
+		memberTeams[member.UserID] = row.teamName
+		memberLocations[member.UserID] = row.location
+		team := &batch.Teams[teamIndex[row.teamName]]
+		team.Members = append(team.Members, domain.TeamMember{
+			UserID:         member.UserID,
+			Username:       member.Username,
+			IsActive:       isActive,
+			Seniority:      seniority,
+			ReviewCapacity: member.ReviewCapacity,
+		})
+	}
+
+	userTeam := func(userID string) (string, error) {
+		if teamName, ok := memberTeams[userID]; ok {
+			return teamName, nil
+		}
+		return s.userRepo.GetUserTeam(ctx, userID)
+	}
+
+	now := time.Now().UTC()
+	prLocations := make(map[string]string)
+
+	for _, row := range rows {
+		req := row.pullRequest
+		if req == nil {
+			continue
+		}
+		if req.PullRequestID == "" || req.PullRequestName == "" || req.AuthorID == "" {
+			reject(row, "pull_request_id, pull_request_name and author_id are required")
+			continue
+		}
+		if first, ok := prLocations[req.PullRequestID]; ok {
+			reject(row, "PR %s is already listed at %s", req.PullRequestID, first)
+			continue
+		}
+
+		exists, err := s.prRepo.PRExists(ctx, req.PullRequestID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if exists {
+			reject(row, "PR %s already exists", req.PullRequestID)
+			continue
+		}
+
+		teamName, err := userTeam(req.AuthorID)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			reject(row, "author %s is not a member of any team", req.AuthorID)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(req.AssignedReviewers) > maxReviewers {
+			reject(row, "at most %d reviewers can be assigned", maxReviewers)
+			continue
+		}
+
+		valid := true
+		for i, reviewerID := range req.AssignedReviewers {
+			if reviewerID == req.AuthorID {
+				reject(row, "author %s cannot review their own PR", reviewerID)
+				valid = false
+				break
+			}
+			if contains(req.AssignedReviewers[:i], reviewerID) {
+				reject(row, "reviewer %s is listed twice", reviewerID)
+				valid = false
+				break
+			}
+
+			reviewerTeam, err := userTeam(reviewerID)
+			if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+				return nil, nil, err
+			}
+			if reviewerTeam != teamName {
+				reject(row, "reviewer %s is not a member of team %s", reviewerID, teamName)
+				valid = false
+				break
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		prLocations[req.PullRequestID] = row.location
+		pr := &domain.PullRequest{
+			PullRequestID:     req.PullRequestID,
+			PullRequestName:   req.PullRequestName,
+			AuthorID:          req.AuthorID,
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: req.AssignedReviewers,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		}
+		if len(pr.AssignedReviewers) > 0 {
+			pr.LastAssignedAt = &now
+		}
+		batch.PullRequests = append(batch.PullRequests, pr)
+
+		for _, reviewerID := range pr.AssignedReviewers {
+			batch.Assignments = append(batch.Assignments, domain.AssignmentRecord{
+				PullRequestID: pr.PullRequestID,
+				TeamName:      teamName,
+				UserID:        reviewerID,
+				Event:         domain.EventAssigned,
+				Reason:        domain.ReasonImported,
+				Actor:         actor,
+				CreatedAt:     now,
+			})
+		}
+	}
+
+	return batch, errs, nil
+}
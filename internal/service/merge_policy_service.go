@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/tracing"
+)
+
+type MergePolicyService struct {
+	policyRepo domain.MergePolicyRepository
+	teamRepo   domain.TeamRepository
+}
+
+func NewMergePolicyService(policyRepo domain.MergePolicyRepository, teamRepo domain.TeamRepository) *MergePolicyService {
+	return &MergePolicyService{
+		policyRepo: policyRepo,
+		teamRepo:   teamRepo,
+	}
+}
+
+func (s *MergePolicyService) SetMergePolicy(ctx context.Context, req dto.SetMergePolicyRequest) (*domain.MergePolicy, error) {
+	ctx, span := tracing.Start(ctx, "MergePolicyService.SetMergePolicy")
+	defer span.End()
+
+	if req.MinApprovals < 0 {
+		return nil, domain.ErrInvalidMergePolicy
+	}
+
+	exists, err := s.teamRepo.TeamExists(ctx, req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	policy := &domain.MergePolicy{
+		TeamName:                req.TeamName,
+		MinApprovals:            req.MinApprovals,
+		BlockOnChangesRequested: req.BlockOnChangesRequested,
+		RequireSeniorApproval:   req.RequireSeniorApproval,
+		ForbidSelfApproval:      req.ForbidSelfApproval,
+	}
+
+	if err := s.policyRepo.SaveMergePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (s *MergePolicyService) GetMergePolicy(ctx context.Context, teamName string) (*domain.MergePolicy, error) {
+	ctx, span := tracing.Start(ctx, "MergePolicyService.GetMergePolicy")
+	defer span.End()
+
+	exists, err := s.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return s.policyRepo.GetMergePolicy(ctx, teamName)
+}
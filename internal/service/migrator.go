@@ -2,47 +2,136 @@ package service
 
 import (
 	"database/sql"
-	"log"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
 	"pull_requests_service/internal/config"
+	"pull_requests_service/migrations"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	_ "github.com/lib/pq"
 )
 
-func RunMigrations(config *config.Config) error {
-	connStr := config.GetDBConnectionString()
+// Migrator applies the SQL migrations embedded in the binary.
+type Migrator struct {
+	m *migrate.Migrate
+}
 
-	db, err := sql.Open("postgres", connStr)
+func NewMigrator(config *config.Config) (*Migrator, error) {
+	db, err := sql.Open("postgres", config.GetDBConnectionString())
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return nil, fmt.Errorf("connect to database: %w", err)
 	}
 
 	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
 	}
 
-	defer db.Close()
-
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
 	if err != nil {
-		log.Fatal("Could not create migration driver: %w", err)
+		db.Close()
+		return nil, fmt.Errorf("create migration driver: %w", err)
+	}
+
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("load embedded migrations: %w", err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		config.DBName,
-		driver)
+	m, err := migrate.NewWithInstance("iofs", source, config.DBName, driver)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create migration instance: %w", err)
+	}
+
+	return &Migrator{m: m}, nil
+}
+
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.m.Up())
+}
+
+// Down rolls back the given number of migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive")
+	}
+	return ignoreNoChange(m.m.Steps(-steps))
+}
+
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+// Version returns the applied version and whether the last migration failed
+// half-way. A database without migrations reports version 0.
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Force marks the database as being at the given version without running
+// anything, which is how a dirty state is cleared after a manual fix.
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// LatestMigrationVersion returns the version of the newest migration
+// embedded in the binary, which is the schema version it expects.
+func LatestMigrationVersion() (uint, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+func RunMigrations(config *config.Config) error {
+	migrator, err := NewMigrator(config)
 	if err != nil {
-		log.Fatal("Could not create migration instance: %w", err)
+		return err
 	}
+	defer migrator.Close()
 
-	err = m.Up()
-	if err != nil && err != migrate.ErrNoChange {
-		log.Fatal("Could not run migrations: %w", err)
+	if err := migrator.Up(); err != nil {
+		return fmt.Errorf("apply migrations: %w", err)
 	}
 
-	log.Println("Migrations applied successfully")
+	slog.Info("Migrations applied successfully")
 	return nil
 }
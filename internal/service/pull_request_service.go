@@ -2,79 +2,329 @@ package service
 
 import (
 	"context"
-	"math/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"pull_requests_service/internal/auth"
 	"pull_requests_service/internal/domain"
 	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/metrics"
+	"pull_requests_service/internal/tracing"
 	"slices"
+	"strconv"
 	"time"
 )
 
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
 type PRService struct {
-	prRepo   domain.PRRepository
-	userRepo domain.UserRepository
+	prRepo         domain.PRRepository
+	userRepo       domain.UserRepository
+	teamRepo       domain.TeamRepository
+	reviewRepo     domain.ReviewRepository
+	policyRepo     domain.MergePolicyRepository
+	exclusionRepo  domain.ReviewerExclusionRepository
+	assignmentRepo domain.AssignmentRepository
+	transactor     domain.Transactor
 }
 
-func NewPRService(prRepo domain.PRRepository, userRepo domain.UserRepository) *PRService {
+func NewPRService(
+	prRepo domain.PRRepository,
+	userRepo domain.UserRepository,
+	teamRepo domain.TeamRepository,
+	reviewRepo domain.ReviewRepository,
+	policyRepo domain.MergePolicyRepository,
+	exclusionRepo domain.ReviewerExclusionRepository,
+	assignmentRepo domain.AssignmentRepository,
+	transactor domain.Transactor,
+) *PRService {
 	return &PRService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
+		prRepo:         prRepo,
+		userRepo:       userRepo,
+		teamRepo:       teamRepo,
+		reviewRepo:     reviewRepo,
+		policyRepo:     policyRepo,
+		exclusionRepo:  exclusionRepo,
+		assignmentRepo: assignmentRepo,
+		transactor:     transactor,
 	}
 }
 
+// CreatePR stores the PR together with its reviewers, their ledger entries
+// and the moved rotation cursor in one transaction. The team's rotation is
+// locked first, so concurrent creates in a team see each other's cursor.
 func (s *PRService) CreatePR(ctx context.Context, req dto.CreatePRRequest) (*domain.PullRequest, error) {
-	exists, err := s.prRepo.PRExists(req.PullRequestID)
+	ctx, span := tracing.Start(ctx, "PRService.CreatePR")
+	defer span.End()
+
+	teamName, err := s.userRepo.GetUserTeam(ctx, req.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr *domain.PullRequest
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.assignmentRepo.LockRotation(ctx, teamName); err != nil {
+			return err
+		}
+
+		exists, err := s.prRepo.PRExists(ctx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.ErrPRExists
+		}
+
+		plan, coAuthors, err := s.planPR(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		pr = &domain.PullRequest{
+			PullRequestID:       req.PullRequestID,
+			PullRequestName:     req.PullRequestName,
+			AuthorID:            req.AuthorID,
+			CoAuthors:           coAuthors,
+			Status:              domain.PRStatusOpen,
+			AssignedReviewers:   plan.Reviewers,
+			ParentPullRequestID: req.ParentPullRequestID,
+			CreatedAt:           now,
+			UpdatedAt:           now,
+		}
+		if len(pr.AssignedReviewers) > 0 {
+			pr.LastAssignedAt = &now
+		}
+
+		if err := s.prRepo.CreatePR(ctx, pr); err != nil {
+			return err
+		}
+		return s.recordPlan(ctx, pr.PullRequestID, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.PRsCreated.Inc()
+	slog.InfoContext(ctx, "Pull request created",
+		"pull_request_id", pr.PullRequestID, "team", teamName, "reviewers", pr.AssignedReviewers)
+	return pr, nil
+}
+
+// PreviewAssignment runs the same reviewer selection as CreatePR without
+// creating the PR or moving the team rotation.
+func (s *PRService) PreviewAssignment(ctx context.Context, req dto.CreatePRRequest) (*domain.AssignmentPlan, error) {
+	ctx, span := tracing.Start(ctx, "PRService.PreviewAssignment")
+	defer span.End()
+
+	plan, _, err := s.planPR(ctx, req)
+	return plan, err
+}
+
+func (s *PRService) planPR(ctx context.Context, req dto.CreatePRRequest) (*domain.AssignmentPlan, []string, error) {
+	teamName, err := s.userRepo.GetUserTeam(ctx, req.AuthorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	author, err := s.userRepo.GetUser(ctx, req.AuthorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	coAuthors, err := s.validateCoAuthors(ctx, req.AuthorID, req.CoAuthors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var parentReviewers []string
+	if req.ParentPullRequestID != "" {
+		parent, err := s.prRepo.GetPR(ctx, req.ParentPullRequestID)
+		if err != nil {
+			return nil, nil, err
+		}
+		parentReviewers = parent.AssignedReviewers
+	}
+
+	plan, err := s.planAssignment(ctx, teamName, assignmentRequest{
+		author:          author,
+		coAuthors:       coAuthors,
+		parentReviewers: parentReviewers,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, coAuthors, nil
+}
+
+func (s *PRService) GetAssignmentHistory(ctx context.Context, prID string) ([]domain.AssignmentRecord, error) {
+	ctx, span := tracing.Start(ctx, "PRService.GetAssignmentHistory")
+	defer span.End()
+
+	exists, err := s.prRepo.PRExists(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
-	if exists {
-		return nil, domain.ErrPRExists
+	if !exists {
+		return nil, domain.ErrPRNotFound
 	}
 
-	teamName, err := s.userRepo.GetUserTeam(req.AuthorID)
+	return s.assignmentRepo.GetAssignmentHistory(ctx, prID)
+}
+
+func (s *PRService) planAssignment(ctx context.Context, teamName string, req assignmentRequest) (*domain.AssignmentPlan, error) {
+	team, err := s.teamRepo.GetTeam(ctx, teamName)
 	if err != nil {
 		return nil, err
 	}
 
-	members, err := s.userRepo.GetActiveTeamMembers(teamName)
+	state, err := s.loadTeamState(ctx, teamName)
 	if err != nil {
 		return nil, err
 	}
 
-	var reviewers []string
-	candidates := make([]domain.TeamMember, 0, len(members))
+	return buildAssignmentPlan(team, *state, req), nil
+}
 
-	for _, member := range members {
-		if member.UserID != req.AuthorID {
-			candidates = append(candidates, member)
+func (s *PRService) loadTeamState(ctx context.Context, teamName string) (*teamState, error) {
+	cursor, err := s.assignmentRepo.GetRotationCursor(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusions, err := s.exclusionRepo.GetExclusions(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	openReviews, err := s.prRepo.GetOpenReviewCounts(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &teamState{
+		cursor:      cursor,
+		exclusions:  exclusions,
+		openReviews: openReviews,
+	}, nil
+}
+
+func (s *PRService) recordPlan(ctx context.Context, prID string, plan *domain.AssignmentPlan) error {
+	now := time.Now().UTC()
+	actor := auth.ActorFromContext(ctx)
+	var records []domain.AssignmentRecord
+	for _, decision := range plan.Decisions {
+		if decision.Chosen {
+			records = append(records, domain.AssignmentRecord{
+				PullRequestID: prID,
+				TeamName:      plan.TeamName,
+				UserID:        decision.UserID,
+				Event:         domain.EventAssigned,
+				Reason:        decision.Reason,
+				Actor:         actor,
+				CreatedAt:     now,
+			})
 		}
 	}
 
-	rand.New(rand.NewSource(time.Now().UnixNano()))
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	if err := s.assignmentRepo.RecordAssignments(ctx, records); err != nil {
+		return err
+	}
 
-	for i := 0; i < len(candidates) && i < 2; i++ {
-		reviewers = append(reviewers, candidates[i].UserID)
+	if cursor, ok := nextCursor(plan); ok {
+		return s.assignmentRepo.SetRotationCursor(ctx, plan.TeamName, cursor)
 	}
+	return nil
+}
 
-	pr := &domain.PullRequest{
-		PullRequestID:     req.PullRequestID,
-		PullRequestName:   req.PullRequestName,
-		AuthorID:          req.AuthorID,
-		Status:            domain.PRStatusOpen,
-		AssignedReviewers: reviewers,
+func (s *PRService) GetPRDetails(ctx context.Context, prID string) (*domain.PRDetails, error) {
+	ctx, span := tracing.Start(ctx, "PRService.GetPRDetails")
+	defer span.End()
+
+	pr, err := s.prRepo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.prRepo.CreatePR(pr); err != nil {
+	author, err := s.getParticipant(ctx, pr.AuthorID)
+	if err != nil {
 		return nil, err
 	}
 
-	return pr, nil
+	reviews, err := s.reviewRepo.GetReviews(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &domain.PRDetails{
+		PullRequest: pr,
+		Author:      *author,
+		Reviewers:   make([]domain.PRReviewer, len(pr.AssignedReviewers)),
+	}
+
+	for i, reviewerID := range pr.AssignedReviewers {
+		participant, err := s.getParticipant(ctx, reviewerID)
+		if err != nil {
+			return nil, err
+		}
+		details.Reviewers[i].PRParticipant = *participant
+
+		for _, review := range reviews {
+			if review.UserID == reviewerID {
+				details.Reviewers[i].Review = &review
+			}
+		}
+	}
+
+	return details, nil
+}
+
+func (s *PRService) getParticipant(ctx context.Context, userID string) (*domain.PRParticipant, error) {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := s.userRepo.GetUserTeam(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PRParticipant{User: *user, TeamName: teamName}, nil
+}
+
+func (s *PRService) validateCoAuthors(ctx context.Context, authorID string, coAuthorIDs []string) ([]string, error) {
+	var coAuthors []string
+	for _, coAuthorID := range coAuthorIDs {
+		if coAuthorID == authorID {
+			return nil, domain.ErrInvalidCoAuthor
+		}
+		if contains(coAuthors, coAuthorID) {
+			continue
+		}
+		if _, err := s.userRepo.GetUser(ctx, coAuthorID); err != nil {
+			return nil, err
+		}
+		coAuthors = append(coAuthors, coAuthorID)
+	}
+	return coAuthors, nil
 }
 
+// MergePR checks the team merge policy and merges the PR in one
+// transaction, holding the team's rotation lock like ReassignPR and
+// SubmitReview, so a review or reassignment cannot land between the check and
+// the status update.
 func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
-	pr, err := s.prRepo.GetPR(prID)
+	ctx, span := tracing.Start(ctx, "PRService.MergePR")
+	defer span.End()
+
+	pr, err := s.prRepo.GetPR(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
@@ -83,67 +333,464 @@ func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullReque
 		return pr, nil
 	}
 
-	pr.Status = domain.PRStatusMerged
-	now := time.Now()
-	pr.MergedAt = &now
+	teamName, err := s.userRepo.GetUserTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	alreadyMerged := false
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.assignmentRepo.LockRotation(ctx, teamName); err != nil {
+			return err
+		}
+
+		pr, err = s.prRepo.GetPR(ctx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status == domain.PRStatusMerged {
+			alreadyMerged = true
+			return nil
+		}
+
+		violations, err := s.checkMergePolicy(ctx, pr, teamName)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			return &domain.MergeBlockedError{Violations: violations}
+		}
+
+		now := time.Now().UTC()
+		pr.Status = domain.PRStatusMerged
+		pr.MergedAt = &now
+		pr.UpdatedAt = now
+
+		if err := s.prRepo.UpdatePR(ctx, pr); err != nil {
+			return err
+		}
+		return s.assignmentRepo.RecordAssignments(ctx, []domain.AssignmentRecord{{
+			PullRequestID: pr.PullRequestID,
+			TeamName:      teamName,
+			UserID:        pr.AuthorID,
+			Event:         domain.EventMerged,
+			Reason:        domain.ReasonPolicySatisfied,
+			Actor:         auth.ActorFromContext(ctx),
+			CreatedAt:     now,
+		}})
+	})
+
+	var blocked *domain.MergeBlockedError
+	if errors.As(err, &blocked) {
+		metrics.MergesBlocked.Inc()
+		slog.InfoContext(ctx, "Merge blocked by policy", "pull_request_id", prID, "violations", len(blocked.Violations))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if alreadyMerged {
+		return pr, nil
+	}
+
+	metrics.PRsMerged.Inc()
+	slog.InfoContext(ctx, "Pull request merged", "pull_request_id", pr.PullRequestID)
+	return pr, nil
+}
+
+func (s *PRService) checkMergePolicy(ctx context.Context, pr *domain.PullRequest, teamName string) ([]domain.PolicyViolation, error) {
+	policy, err := s.policyRepo.GetMergePolicy(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.reviewRepo.GetReviews(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.Evaluate(pr, reviews, team.Members), nil
+}
+
+// SubmitReview saves the review of an assigned reviewer. A caller bound to a
+// user may only review as that user. The PR is read again under the team's
+// rotation lock so the review cannot race a merge or a reassignment.
+func (s *PRService) SubmitReview(ctx context.Context, req dto.SubmitReviewRequest) (*domain.Review, error) {
+	ctx, span := tracing.Start(ctx, "PRService.SubmitReview")
+	defer span.End()
+
+	state := domain.ReviewState(req.State)
+	if !state.IsValid() {
+		return nil, domain.ErrInvalidReviewState
+	}
+
+	if identity, ok := auth.FromContext(ctx); ok && identity.UserID != "" && identity.UserID != req.UserID {
+		return nil, domain.ErrForbidden
+	}
+
+	pr, err := s.getReviewablePR(ctx, req.PullRequestID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := s.userRepo.GetUserTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &domain.Review{
+		PullRequestID: pr.PullRequestID,
+		UserID:        req.UserID,
+		State:         state,
+		SubmittedAt:   time.Now().UTC(),
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.assignmentRepo.LockRotation(ctx, teamName); err != nil {
+			return err
+		}
+
+		if _, err := s.getReviewablePR(ctx, req.PullRequestID, req.UserID); err != nil {
+			return err
+		}
+
+		if err := s.reviewRepo.SaveReview(ctx, review); err != nil {
+			return err
+		}
+		if err := s.prRepo.TouchPR(ctx, pr.PullRequestID, review.SubmittedAt); err != nil {
+			return err
+		}
+		return s.assignmentRepo.RecordAssignments(ctx, []domain.AssignmentRecord{{
+			PullRequestID: pr.PullRequestID,
+			TeamName:      teamName,
+			UserID:        review.UserID,
+			Event:         domain.EventReviewed,
+			Reason:        domain.AssignmentReason(review.State),
+			Actor:         auth.ActorFromContext(ctx),
+			CreatedAt:     review.SubmittedAt,
+		}})
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.prRepo.UpdatePR(pr); err != nil {
+	return review, nil
+}
+
+func (s *PRService) getReviewablePR(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetPR(ctx, prID)
+	if err != nil {
 		return nil, err
 	}
 
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.ErrPRMerged
+	}
+
+	if !contains(pr.AssignedReviewers, userID) {
+		return nil, domain.ErrNotAssigned
+	}
+
 	return pr, nil
 }
 
+// ReassignPR replaces a reviewer and records the change in one transaction,
+// holding the team's rotation lock like CreatePR. The PR is read again under
+// the lock so a concurrent merge or reassignment is not overwritten.
 func (s *PRService) ReassignPR(ctx context.Context, prID, oldUserID string) (string, *domain.PullRequest, error) {
-	pr, err := s.prRepo.GetPR(prID)
+	ctx, span := tracing.Start(ctx, "PRService.ReassignPR")
+	defer span.End()
+
+	pr, err := s.getReassignablePR(ctx, prID, oldUserID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	teamName, err := s.userRepo.GetUserTeam(ctx, oldUserID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var newUserID string
+	var reason domain.AssignmentReason
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.assignmentRepo.LockRotation(ctx, teamName); err != nil {
+			return err
+		}
+
+		pr, err = s.getReassignablePR(ctx, prID, oldUserID)
+		if err != nil {
+			return err
+		}
+
+		newUserID, reason, err = s.pickReassignment(ctx, pr, teamName, oldUserID)
+		if err != nil {
+			return err
+		}
+
+		for i, reviewer := range pr.AssignedReviewers {
+			if reviewer == oldUserID {
+				pr.AssignedReviewers[i] = newUserID
+				break
+			}
+		}
+
+		now := time.Now().UTC()
+		pr.UpdatedAt = now
+		pr.LastAssignedAt = &now
+
+		if err := s.prRepo.UpdatePR(ctx, pr); err != nil {
+			return err
+		}
+
+		actor := auth.ActorFromContext(ctx)
+		err = s.assignmentRepo.RecordAssignments(ctx, []domain.AssignmentRecord{
+			{
+				PullRequestID: prID,
+				TeamName:      teamName,
+				UserID:        oldUserID,
+				Event:         domain.EventUnassigned,
+				Reason:        domain.ReasonReassigned,
+				Actor:         actor,
+				CreatedAt:     now,
+			},
+			{
+				PullRequestID: prID,
+				TeamName:      teamName,
+				UserID:        newUserID,
+				Event:         domain.EventAssigned,
+				Reason:        reason,
+				Actor:         actor,
+				CreatedAt:     now,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if reason == domain.ReasonRotation {
+			return s.assignmentRepo.SetRotationCursor(ctx, teamName, newUserID)
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrNoCandidate) {
+		metrics.NoCandidate.Inc()
+	}
 	if err != nil {
 		return "", nil, err
 	}
 
+	metrics.Reassignments.WithLabelValues(string(reason)).Inc()
+	slog.InfoContext(ctx, "Reviewer reassigned",
+		"pull_request_id", prID, "old_reviewer", oldUserID, "new_reviewer", newUserID, "reason", reason)
+	return newUserID, pr, nil
+}
+
+func (s *PRService) getReassignablePR(ctx context.Context, prID, oldUserID string) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
 	if pr.Status == domain.PRStatusMerged {
-		return "", nil, domain.ErrPRMerged
+		return nil, domain.ErrPRMerged
 	}
 
 	if !contains(pr.AssignedReviewers, oldUserID) {
-		return "", nil, domain.ErrNotAssigned
+		return nil, domain.ErrNotAssigned
 	}
 
-	teamName, err := s.userRepo.GetUserTeam(oldUserID)
+	return pr, nil
+}
+
+// pickReassignment chooses the replacement for oldUserID among the active
+// members of the team, keeping the seniority guarantees of the remaining
+// reviewers.
+func (s *PRService) pickReassignment(ctx context.Context, pr *domain.PullRequest, teamName, oldUserID string) (string, domain.AssignmentReason, error) {
+	team, err := s.teamRepo.GetTeam(ctx, teamName)
 	if err != nil {
-		return "", nil, err
+		return "", "", err
 	}
 
-	members, err := s.userRepo.GetActiveTeamMembers(teamName)
+	author, err := s.userRepo.GetUser(ctx, pr.AuthorID)
 	if err != nil {
-		return "", nil, err
+		return "", "", err
 	}
 
-	var candidates []string
-	for _, member := range members {
-		if member.UserID != oldUserID && member.UserID != pr.AuthorID && !contains(pr.AssignedReviewers, member.UserID) {
-			candidates = append(candidates, member.UserID)
+	var remaining []domain.TeamMember
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == oldUserID {
+			continue
+		}
+		reviewer, err := s.userRepo.GetUser(ctx, reviewerID)
+		if err != nil {
+			return "", "", err
 		}
+		remaining = append(remaining, *reviewer)
 	}
 
-	if len(candidates) == 0 {
-		return "", nil, domain.ErrNoCandidate
+	state, err := s.loadTeamState(ctx, teamName)
+	if err != nil {
+		return "", "", err
 	}
 
-	rand.New(rand.NewSource(time.Now().UnixNano()))
-	newUserID := candidates[rand.Intn(len(candidates))]
+	blocked := blockedReviewers(append([]string{pr.AuthorID}, pr.CoAuthors...), state.exclusions)
 
-	for i, reviewer := range pr.AssignedReviewers {
-		if reviewer == oldUserID {
-			pr.AssignedReviewers[i] = newUserID
-			break
+	var candidates []domain.TeamMember
+	for _, member := range rotationOrder(team.Members, state.cursor) {
+		if !member.IsActive || isOverCapacity(member, state.openReviews) || blocked[member.UserID] {
+			continue
+		}
+		if member.UserID != oldUserID && !contains(pr.AssignedReviewers, member.UserID) {
+			candidates = append(candidates, member)
 		}
 	}
 
-	if err := s.prRepo.UpdatePR(pr); err != nil {
-		return "", nil, err
+	newUserID, reason, ok := pickReplacement(author, remaining, candidates)
+	if !ok {
+		return "", "", domain.ErrNoCandidate
 	}
+	return newUserID, reason, nil
+}
 
-	return newUserID, pr, nil
+func (s *PRService) ListPRs(ctx context.Context, req dto.ListPRsRequest) ([]*domain.PullRequest, string, error) {
+	ctx, span := tracing.Start(ctx, "PRService.ListPRs")
+	defer span.End()
+
+	filter, err := parseListFilter(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	prs, err := s.prRepo.ListPRs(ctx, *filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(prs) <= limit {
+		return prs, "", nil
+	}
+
+	prs = prs[:limit]
+	return prs, encodeCursor(*filter, prs[limit-1]), nil
+}
+
+func parseListFilter(req dto.ListPRsRequest) (*domain.PRListFilter, error) {
+	filter := &domain.PRListFilter{
+		Status:       domain.PRStatus(req.Status),
+		AuthorID:     req.AuthorID,
+		ReviewerID:   req.ReviewerID,
+		TeamName:     req.TeamName,
+		NameContains: req.Query,
+		SortBy:       domain.PRSortCreatedAt,
+		Limit:        defaultPageSize,
+	}
+
+	if filter.Status != "" && filter.Status != domain.PRStatusOpen && filter.Status != domain.PRStatusMerged {
+		return nil, domain.ErrInvalidFilter
+	}
+
+	if req.SortBy != "" {
+		filter.SortBy = domain.PRSortField(req.SortBy)
+		if !filter.SortBy.IsValid() {
+			return nil, domain.ErrInvalidFilter
+		}
+	}
+
+	switch req.Order {
+	case "", "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		return nil, domain.ErrInvalidFilter
+	}
+
+	if req.Limit != "" {
+		limit, err := strconv.Atoi(req.Limit)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return nil, domain.ErrInvalidFilter
+		}
+		filter.Limit = limit
+	}
+
+	ranges := []struct {
+		value  string
+		target **time.Time
+	}{
+		{req.CreatedFrom, &filter.CreatedFrom},
+		{req.CreatedTo, &filter.CreatedTo},
+		{req.MergedFrom, &filter.MergedFrom},
+		{req.MergedTo, &filter.MergedTo},
+		{req.UpdatedFrom, &filter.UpdatedFrom},
+		{req.UpdatedTo, &filter.UpdatedTo},
+		{req.LastAssignedFrom, &filter.LastAssignedFrom},
+		{req.LastAssignedTo, &filter.LastAssignedTo},
+	}
+	for _, r := range ranges {
+		if r.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, r.value)
+		if err != nil {
+			return nil, domain.ErrInvalidFilter
+		}
+		*r.target = &t
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.SortBy != filter.SortBy || cursor.Descending != filter.Descending {
+			return nil, domain.ErrInvalidCursor
+		}
+		filter.After = cursor
+	}
+
+	return filter, nil
+}
+
+func encodeCursor(filter domain.PRListFilter, pr *domain.PullRequest) string {
+	cursor := domain.PRListCursor{
+		SortBy:        filter.SortBy,
+		Descending:    filter.Descending,
+		PullRequestID: pr.PullRequestID,
+	}
+	switch filter.SortBy {
+	case domain.PRSortCreatedAt:
+		cursor.SortValue = pr.CreatedAt.Format(time.RFC3339Nano)
+	case domain.PRSortUpdatedAt:
+		cursor.SortValue = pr.UpdatedAt.Format(time.RFC3339Nano)
+	case domain.PRSortName:
+		cursor.SortValue = pr.PullRequestName
+	case domain.PRSortID:
+		cursor.SortValue = pr.PullRequestID
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(token string) (*domain.PRListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var cursor domain.PRListCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.PullRequestID == "" {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return &cursor, nil
 }
 
 func contains(slice []string, item string) bool {
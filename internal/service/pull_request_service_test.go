@@ -0,0 +1,149 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2025, 11, 3, 10, 15, 30, 123456789, time.UTC)
+	pr := &domain.PullRequest{
+		PullRequestID:   "pr-42",
+		PullRequestName: "Add feature",
+		CreatedAt:       created,
+		UpdatedAt:       created.Add(time.Hour),
+	}
+
+	tests := []struct {
+		sortBy     domain.PRSortField
+		descending bool
+		wantValue  string
+	}{
+		{domain.PRSortCreatedAt, false, "2025-11-03T10:15:30.123456789Z"},
+		{domain.PRSortUpdatedAt, true, "2025-11-03T11:15:30.123456789Z"},
+		{domain.PRSortName, false, "Add feature"},
+		{domain.PRSortID, true, "pr-42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sortBy), func(t *testing.T) {
+			filter := domain.PRListFilter{SortBy: tt.sortBy, Descending: tt.descending}
+			cursor, err := decodeCursor(encodeCursor(filter, pr))
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			want := domain.PRListCursor{
+				SortBy:        tt.sortBy,
+				Descending:    tt.descending,
+				SortValue:     tt.wantValue,
+				PullRequestID: "pr-42",
+			}
+			if *cursor != want {
+				t.Errorf("decodeCursor() = %+v, want %+v", *cursor, want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "!!!"},
+		{name: "not JSON", token: encode("created_at|pr-1")},
+		{name: "missing id", token: encode(`{"s":"created_at","v":"x"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.token); !errors.Is(err, domain.ErrInvalidCursor) {
+				t.Errorf("decodeCursor(%q) error = %v, want ErrInvalidCursor", tt.token, err)
+			}
+		})
+	}
+}
+
+func TestParseListFilter(t *testing.T) {
+	namePR := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "a"}
+	nameCursor := encodeCursor(domain.PRListFilter{SortBy: domain.PRSortName}, namePR)
+	nameDescCursor := encodeCursor(domain.PRListFilter{SortBy: domain.PRSortName, Descending: true}, namePR)
+
+	tests := []struct {
+		name    string
+		req     dto.ListPRsRequest
+		wantErr error
+		check   func(t *testing.T, filter *domain.PRListFilter)
+	}{
+		{
+			name: "defaults",
+			req:  dto.ListPRsRequest{},
+			check: func(t *testing.T, filter *domain.PRListFilter) {
+				if filter.SortBy != domain.PRSortCreatedAt || filter.Descending || filter.Limit != defaultPageSize {
+					t.Errorf("got sort %q desc %v limit %d", filter.SortBy, filter.Descending, filter.Limit)
+				}
+			},
+		},
+		{
+			name: "sorting, order and limit",
+			req:  dto.ListPRsRequest{SortBy: "pull_request_name", Order: "desc", Limit: "10"},
+			check: func(t *testing.T, filter *domain.PRListFilter) {
+				if filter.SortBy != domain.PRSortName || !filter.Descending || filter.Limit != 10 {
+					t.Errorf("got sort %q desc %v limit %d", filter.SortBy, filter.Descending, filter.Limit)
+				}
+			},
+		},
+		{
+			name: "time range",
+			req:  dto.ListPRsRequest{CreatedFrom: "2025-01-01T00:00:00Z", LastAssignedTo: "2025-02-01T00:00:00+03:00"},
+			check: func(t *testing.T, filter *domain.PRListFilter) {
+				if filter.CreatedFrom == nil || !filter.CreatedFrom.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("CreatedFrom = %v", filter.CreatedFrom)
+				}
+				if filter.LastAssignedTo == nil || !filter.LastAssignedTo.Equal(time.Date(2025, 1, 31, 21, 0, 0, 0, time.UTC)) {
+					t.Errorf("LastAssignedTo = %v", filter.LastAssignedTo)
+				}
+			},
+		},
+		{
+			name: "cursor of the same sort",
+			req:  dto.ListPRsRequest{SortBy: "pull_request_name", Cursor: nameCursor},
+			check: func(t *testing.T, filter *domain.PRListFilter) {
+				if filter.After == nil || filter.After.PullRequestID != "pr-1" {
+					t.Errorf("After = %+v", filter.After)
+				}
+			},
+		},
+		{name: "unknown status", req: dto.ListPRsRequest{Status: "CLOSED"}, wantErr: domain.ErrInvalidFilter},
+		{name: "unknown sort field", req: dto.ListPRsRequest{SortBy: "author_id"}, wantErr: domain.ErrInvalidFilter},
+		{name: "unknown order", req: dto.ListPRsRequest{Order: "up"}, wantErr: domain.ErrInvalidFilter},
+		{name: "zero limit", req: dto.ListPRsRequest{Limit: "0"}, wantErr: domain.ErrInvalidFilter},
+		{name: "limit over the maximum", req: dto.ListPRsRequest{Limit: "201"}, wantErr: domain.ErrInvalidFilter},
+		{name: "time without zone", req: dto.ListPRsRequest{MergedFrom: "2025-01-01T00:00:00"}, wantErr: domain.ErrInvalidFilter},
+		{name: "cursor of another sort", req: dto.ListPRsRequest{Cursor: nameCursor}, wantErr: domain.ErrInvalidCursor},
+		{
+			name:    "cursor of the other order",
+			req:     dto.ListPRsRequest{SortBy: "pull_request_name", Cursor: nameDescCursor},
+			wantErr: domain.ErrInvalidCursor,
+		},
+		{name: "malformed cursor", req: dto.ListPRsRequest{Cursor: "???"}, wantErr: domain.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseListFilter(tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseListFilter() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, filter)
+			}
+		})
+	}
+}
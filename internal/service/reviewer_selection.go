@@ -0,0 +1,222 @@
+package service
+
+import (
+	"pull_requests_service/internal/domain"
+	"slices"
+	"strings"
+)
+
+const maxReviewers = 2
+
+type assignmentRequest struct {
+	author          *domain.TeamMember
+	coAuthors       []string
+	parentReviewers []string
+}
+
+// rotationOrder sorts the members by user id and rotates the result so that
+// the member right after the cursor comes first. Selection always walks the
+// team in this order, which makes every assignment reproducible.
+func rotationOrder(members []domain.TeamMember, cursor string) []domain.TeamMember {
+	sorted := slices.Clone(members)
+	slices.SortFunc(sorted, func(a, b domain.TeamMember) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	start := slices.IndexFunc(sorted, func(m domain.TeamMember) bool { return m.UserID > cursor })
+	if start < 0 {
+		start = 0
+	}
+
+	return append(sorted[start:], sorted[:start]...)
+}
+
+// teamState is everything about the team that reviewer selection depends on
+// besides its members.
+type teamState struct {
+	cursor      string
+	exclusions  []domain.ReviewerExclusion
+	openReviews map[string]int
+}
+
+// buildAssignmentPlan decides for every team member whether they review the
+// PR and records the reason either way.
+func buildAssignmentPlan(team *domain.Team, state teamState, req assignmentRequest) *domain.AssignmentPlan {
+	ordered := rotationOrder(team.Members, state.cursor)
+	blocked := blockedReviewers(append([]string{req.author.UserID}, req.coAuthors...), state.exclusions)
+
+	plan := &domain.AssignmentPlan{
+		TeamName:  team.TeamName,
+		Cursor:    state.cursor,
+		Decisions: make([]domain.CandidateDecision, len(ordered)),
+	}
+	byUser := make(map[string]*domain.CandidateDecision, len(ordered))
+
+	var candidates []domain.TeamMember
+	for i, member := range ordered {
+		decision := &plan.Decisions[i]
+		decision.UserID = member.UserID
+		decision.Position = i + 1
+		byUser[member.UserID] = decision
+
+		switch {
+		case member.UserID == req.author.UserID:
+			decision.Reason = domain.ReasonAuthor
+		case slices.Contains(req.coAuthors, member.UserID):
+			decision.Reason = domain.ReasonCoAuthor
+		case !member.IsActive:
+			decision.Reason = domain.ReasonInactive
+		case isOverCapacity(member, state.openReviews):
+			decision.Reason = domain.ReasonOverCapacity
+		case blocked[member.UserID]:
+			decision.Reason = domain.ReasonExcludedPair
+		default:
+			candidates = append(candidates, member)
+		}
+	}
+
+	candidates = preferReviewers(candidates, req.parentReviewers)
+	picked := pickReviewers(req.author, candidates)
+
+	for i, candidate := range candidates {
+		decision := byUser[candidate.UserID]
+		switch {
+		case !slices.Contains(picked, i) && len(picked) == 0:
+			decision.Reason = domain.ReasonJuniorOnly
+		case !slices.Contains(picked, i):
+			decision.Reason = domain.ReasonSlotsFilled
+		case slices.Contains(req.parentReviewers, candidate.UserID):
+			decision.Chosen, decision.Reason = true, domain.ReasonParentReviewer
+		case i < maxReviewers:
+			decision.Chosen, decision.Reason = true, domain.ReasonRotation
+		default:
+			decision.Chosen, decision.Reason = true, domain.ReasonSeniorityRule
+		}
+	}
+
+	for _, i := range picked {
+		plan.Reviewers = append(plan.Reviewers, candidates[i].UserID)
+	}
+
+	return plan
+}
+
+// nextCursor returns the member the rotation should continue after: the last
+// reviewer picked by rotation. Reviewers picked for any other reason do not
+// move the cursor, so nobody is skipped because of them.
+func nextCursor(plan *domain.AssignmentPlan) (string, bool) {
+	for i := len(plan.Decisions) - 1; i >= 0; i-- {
+		if plan.Decisions[i].Chosen && plan.Decisions[i].Reason == domain.ReasonRotation {
+			return plan.Decisions[i].UserID, true
+		}
+	}
+	return "", false
+}
+
+// pickReviewers chooses up to maxReviewers from candidates, which must be
+// given in order of preference, and returns their indices in that order.
+// The pair always contains a senior when one is available, and a junior
+// author is never reviewed by juniors only: if no senior or middle candidate
+// exists, nobody is picked.
+func pickReviewers(author *domain.TeamMember, candidates []domain.TeamMember) []int {
+	var picked []int
+
+	if i := slices.IndexFunc(candidates, isSenior); i >= 0 {
+		picked = append(picked, i)
+	} else if author.Seniority == domain.SeniorityJunior {
+		i := slices.IndexFunc(candidates, isNotJunior)
+		if i < 0 {
+			return nil
+		}
+		picked = append(picked, i)
+	}
+
+	for i := 0; i < len(candidates) && len(picked) < maxReviewers; i++ {
+		if !slices.Contains(picked, i) {
+			picked = append(picked, i)
+		}
+	}
+	slices.Sort(picked)
+
+	return picked
+}
+
+// preferReviewers moves the preferred users that are still candidates to the
+// front, so that pickReviewers reuses them before anyone else. The relative
+// order of the remaining candidates is kept.
+func preferReviewers(candidates []domain.TeamMember, preferred []string) []domain.TeamMember {
+	if len(preferred) == 0 {
+		return candidates
+	}
+
+	ordered := make([]domain.TeamMember, 0, len(candidates))
+	for _, userID := range preferred {
+		if i := slices.IndexFunc(candidates, func(m domain.TeamMember) bool { return m.UserID == userID }); i >= 0 {
+			ordered = append(ordered, candidates[i])
+		}
+	}
+	for _, candidate := range candidates {
+		if !slices.Contains(preferred, candidate.UserID) {
+			ordered = append(ordered, candidate)
+		}
+	}
+	return ordered
+}
+
+// pickReplacement chooses a reviewer to join the remaining ones so that the
+// resulting set keeps the seniority guarantees of pickReviewers. It returns
+// false when no candidate can keep them.
+func pickReplacement(author *domain.TeamMember, remaining, candidates []domain.TeamMember) (string, domain.AssignmentReason, bool) {
+	if len(candidates) == 0 {
+		return "", "", false
+	}
+
+	pick := func(i int) (string, domain.AssignmentReason, bool) {
+		if i == 0 {
+			return candidates[i].UserID, domain.ReasonRotation, true
+		}
+		return candidates[i].UserID, domain.ReasonSeniorityRule, true
+	}
+
+	if !slices.ContainsFunc(remaining, isSenior) {
+		if i := slices.IndexFunc(candidates, isSenior); i >= 0 {
+			return pick(i)
+		}
+	}
+
+	if author.Seniority == domain.SeniorityJunior && !slices.ContainsFunc(remaining, isNotJunior) {
+		if i := slices.IndexFunc(candidates, isNotJunior); i >= 0 {
+			return pick(i)
+		}
+		return "", "", false
+	}
+
+	return pick(0)
+}
+
+// blockedReviewers returns the users that must not review a PR written by the
+// given authors: the authors themselves and everyone they are excluded with.
+func blockedReviewers(authors []string, exclusions []domain.ReviewerExclusion) map[string]bool {
+	blocked := make(map[string]bool, len(authors))
+	for _, authorID := range authors {
+		blocked[authorID] = true
+		for _, exclusion := range exclusions {
+			if partner, ok := exclusion.Partner(authorID); ok {
+				blocked[partner] = true
+			}
+		}
+	}
+	return blocked
+}
+
+func isOverCapacity(member domain.TeamMember, openReviews map[string]int) bool {
+	return member.ReviewCapacity > 0 && openReviews[member.UserID] >= member.ReviewCapacity
+}
+
+func isSenior(member domain.TeamMember) bool {
+	return member.Seniority == domain.SenioritySenior
+}
+
+func isNotJunior(member domain.TeamMember) bool {
+	return member.Seniority != domain.SeniorityJunior
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"pull_requests_service/internal/domain"
+	"slices"
+	"testing"
+)
+
+func member(userID string, seniority domain.Seniority) domain.TeamMember {
+	return domain.TeamMember{UserID: userID, Username: userID, IsActive: true, Seniority: seniority}
+}
+
+func userIDs(members []domain.TeamMember) []string {
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.UserID
+	}
+	return ids
+}
+
+func TestPickReviewers(t *testing.T) {
+	var (
+		junior = domain.SeniorityJunior
+		middle = domain.SeniorityMiddle
+		senior = domain.SenioritySenior
+	)
+
+	tests := []struct {
+		name       string
+		author     domain.Seniority
+		candidates []domain.TeamMember
+		want       []int
+	}{
+		{
+			name:       "no candidates",
+			author:     middle,
+			candidates: nil,
+			want:       nil,
+		},
+		{
+			name:       "first two in order",
+			author:     middle,
+			candidates: []domain.TeamMember{member("u1", middle), member("u2", middle), member("u3", middle)},
+			want:       []int{0, 1},
+		},
+		{
+			name:       "senior joins the pair",
+			author:     middle,
+			candidates: []domain.TeamMember{member("u1", middle), member("u2", junior), member("u3", senior)},
+			want:       []int{0, 2},
+		},
+		{
+			name:       "senior first takes one slot",
+			author:     junior,
+			candidates: []domain.TeamMember{member("u1", senior), member("u2", junior), member("u3", middle)},
+			want:       []int{0, 1},
+		},
+		{
+			name:       "junior author gets a middle when there is no senior",
+			author:     junior,
+			candidates: []domain.TeamMember{member("u1", junior), member("u2", junior), member("u3", middle)},
+			want:       []int{0, 2},
+		},
+		{
+			name:       "junior author is never reviewed by juniors only",
+			author:     junior,
+			candidates: []domain.TeamMember{member("u1", junior), member("u2", junior)},
+			want:       nil,
+		},
+		{
+			name:       "middle author may be reviewed by juniors",
+			author:     middle,
+			candidates: []domain.TeamMember{member("u1", junior), member("u2", junior)},
+			want:       []int{0, 1},
+		},
+		{
+			name:       "single candidate",
+			author:     senior,
+			candidates: []domain.TeamMember{member("u1", junior)},
+			want:       []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			author := member("author", tt.author)
+			got := pickReviewers(&author, tt.candidates)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("pickReviewers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickReplacement(t *testing.T) {
+	var (
+		junior = domain.SeniorityJunior
+		middle = domain.SeniorityMiddle
+		senior = domain.SenioritySenior
+	)
+
+	tests := []struct {
+		name       string
+		author     domain.Seniority
+		remaining  []domain.TeamMember
+		candidates []domain.TeamMember
+		wantUser   string
+		wantReason domain.AssignmentReason
+		wantOK     bool
+	}{
+		{
+			name:       "no candidates",
+			author:     middle,
+			remaining:  []domain.TeamMember{member("r1", senior)},
+			candidates: nil,
+			wantOK:     false,
+		},
+		{
+			name:       "next in rotation",
+			author:     middle,
+			remaining:  []domain.TeamMember{member("r1", senior)},
+			candidates: []domain.TeamMember{member("u1", junior), member("u2", senior)},
+			wantUser:   "u1",
+			wantReason: domain.ReasonRotation,
+			wantOK:     true,
+		},
+		{
+			name:       "senior replaces the only senior",
+			author:     middle,
+			remaining:  []domain.TeamMember{member("r1", middle)},
+			candidates: []domain.TeamMember{member("u1", middle), member("u2", senior)},
+			wantUser:   "u2",
+			wantReason: domain.ReasonSeniorityRule,
+			wantOK:     true,
+		},
+		{
+			name:       "senior that is next keeps the rotation reason",
+			author:     middle,
+			remaining:  nil,
+			candidates: []domain.TeamMember{member("u1", senior), member("u2", middle)},
+			wantUser:   "u1",
+			wantReason: domain.ReasonRotation,
+			wantOK:     true,
+		},
+		{
+			name:       "junior author with a junior left gets a middle",
+			author:     junior,
+			remaining:  []domain.TeamMember{member("r1", junior)},
+			candidates: []domain.TeamMember{member("u1", junior), member("u2", middle)},
+			wantUser:   "u2",
+			wantReason: domain.ReasonSeniorityRule,
+			wantOK:     true,
+		},
+		{
+			name:       "junior author with only juniors available",
+			author:     junior,
+			remaining:  []domain.TeamMember{member("r1", junior)},
+			candidates: []domain.TeamMember{member("u1", junior)},
+			wantOK:     false,
+		},
+		{
+			name:       "junior author already has a middle",
+			author:     junior,
+			remaining:  []domain.TeamMember{member("r1", middle)},
+			candidates: []domain.TeamMember{member("u1", junior)},
+			wantUser:   "u1",
+			wantReason: domain.ReasonRotation,
+			wantOK:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			author := member("author", tt.author)
+			user, reason, ok := pickReplacement(&author, tt.remaining, tt.candidates)
+			if user != tt.wantUser || reason != tt.wantReason || ok != tt.wantOK {
+				t.Errorf("pickReplacement() = (%q, %q, %v), want (%q, %q, %v)",
+					user, reason, ok, tt.wantUser, tt.wantReason, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBlockedReviewers(t *testing.T) {
+	exclusions := []domain.ReviewerExclusion{
+		domain.NewReviewerExclusion("backend", "u1", "u2"),
+		domain.NewReviewerExclusion("backend", "u4", "u3"),
+	}
+
+	tests := []struct {
+		name    string
+		authors []string
+		want    []string
+	}{
+		{name: "author without exclusions", authors: []string{"u5"}, want: []string{"u5"}},
+		{name: "author listed first in the pair", authors: []string{"u1"}, want: []string{"u1", "u2"}},
+		{name: "author listed second in the pair", authors: []string{"u4"}, want: []string{"u3", "u4"}},
+		{name: "co-author exclusions apply too", authors: []string{"u5", "u2"}, want: []string{"u1", "u2", "u5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked := blockedReviewers(tt.authors, exclusions)
+			var got []string
+			for userID := range blocked {
+				got = append(got, userID)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("blockedReviewers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreferReviewers(t *testing.T) {
+	candidates := []domain.TeamMember{
+		member("u1", domain.SeniorityMiddle),
+		member("u2", domain.SeniorityMiddle),
+		member("u3", domain.SeniorityMiddle),
+		member("u4", domain.SeniorityMiddle),
+	}
+
+	tests := []struct {
+		name      string
+		preferred []string
+		want      []string
+	}{
+		{name: "no preference", preferred: nil, want: []string{"u1", "u2", "u3", "u4"}},
+		{name: "preferred move to the front", preferred: []string{"u3"}, want: []string{"u3", "u1", "u2", "u4"}},
+		{name: "preferred keep their own order", preferred: []string{"u4", "u2"}, want: []string{"u4", "u2", "u1", "u3"}},
+		{name: "preferred users that are not candidates are skipped", preferred: []string{"u9", "u2"}, want: []string{"u2", "u1", "u3", "u4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userIDs(preferReviewers(candidates, tt.preferred))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("preferReviewers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotationOrder(t *testing.T) {
+	members := []domain.TeamMember{
+		member("c", domain.SeniorityMiddle),
+		member("a", domain.SeniorityMiddle),
+		member("b", domain.SeniorityMiddle),
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+		want   []string
+	}{
+		{name: "no cursor starts at the first id", cursor: "", want: []string{"a", "b", "c"}},
+		{name: "continues after the cursor", cursor: "a", want: []string{"b", "c", "a"}},
+		{name: "wraps around after the last id", cursor: "c", want: []string{"a", "b", "c"}},
+		{name: "cursor of a member that left the team", cursor: "bb", want: []string{"c", "a", "b"}},
+		{name: "cursor past every id", cursor: "z", want: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userIDs(rotationOrder(members, tt.cursor))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rotationOrder(%q) = %v, want %v", tt.cursor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildAssignmentPlan(t *testing.T) {
+	type decision = domain.CandidateDecision
+
+	middle := func(userID string) domain.TeamMember { return member(userID, domain.SeniorityMiddle) }
+	inactive := middle("b")
+	inactive.IsActive = false
+	busy := middle("c")
+	busy.ReviewCapacity = 1
+
+	tests := []struct {
+		name          string
+		members       []domain.TeamMember
+		state         teamState
+		author        domain.TeamMember
+		coAuthors     []string
+		parents       []string
+		wantReviewers []string
+		wantDecisions []decision
+		wantCursor    string
+	}{
+		{
+			name:          "rotation continues after the cursor",
+			members:       []domain.TeamMember{middle("a"), middle("b"), middle("c"), middle("d"), middle("e")},
+			state:         teamState{cursor: "b"},
+			author:        middle("a"),
+			wantReviewers: []string{"c", "d"},
+			wantDecisions: []decision{
+				{UserID: "c", Position: 1, Chosen: true, Reason: domain.ReasonRotation},
+				{UserID: "d", Position: 2, Chosen: true, Reason: domain.ReasonRotation},
+				{UserID: "e", Position: 3, Reason: domain.ReasonSlotsFilled},
+				{UserID: "a", Position: 4, Reason: domain.ReasonAuthor},
+				{UserID: "b", Position: 5, Reason: domain.ReasonSlotsFilled},
+			},
+			wantCursor: "d",
+		},
+		{
+			name:    "inactive, busy and excluded members are skipped",
+			members: []domain.TeamMember{middle("a"), inactive, busy, middle("d"), middle("e"), middle("f")},
+			state: teamState{
+				exclusions:  []domain.ReviewerExclusion{domain.NewReviewerExclusion("backend", "d", "a")},
+				openReviews: map[string]int{"c": 1},
+			},
+			author:        middle("a"),
+			wantReviewers: []string{"e", "f"},
+			wantDecisions: []decision{
+				{UserID: "a", Position: 1, Reason: domain.ReasonAuthor},
+				{UserID: "b", Position: 2, Reason: domain.ReasonInactive},
+				{UserID: "c", Position: 3, Reason: domain.ReasonOverCapacity},
+				{UserID: "d", Position: 4, Reason: domain.ReasonExcludedPair},
+				{UserID: "e", Position: 5, Chosen: true, Reason: domain.ReasonRotation},
+				{UserID: "f", Position: 6, Chosen: true, Reason: domain.ReasonRotation},
+			},
+			wantCursor: "f",
+		},
+		{
+			name:          "co-authors do not review",
+			members:       []domain.TeamMember{middle("a"), middle("b"), middle("c"), middle("d")},
+			author:        middle("a"),
+			coAuthors:     []string{"b"},
+			wantReviewers: []string{"c", "d"},
+			wantDecisions: []decision{
+				{UserID: "a", Position: 1, Reason: domain.ReasonAuthor},
+				{UserID: "b", Position: 2, Reason: domain.ReasonCoAuthor},
+				{UserID: "c", Position: 3, Chosen: true, Reason: domain.ReasonRotation},
+				{UserID: "d", Position: 4, Chosen: true, Reason: domain.ReasonRotation},
+			},
+			wantCursor: "d",
+		},
+		{
+			name:          "senior picked by the seniority rule does not move the cursor",
+			members:       []domain.TeamMember{middle("a"), middle("b"), middle("c"), member("d", domain.SenioritySenior)},
+			author:        middle("a"),
+			wantReviewers: []string{"b", "d"},
+			wantDecisions: []decision{
+				{UserID: "a", Position: 1, Reason: domain.ReasonAuthor},
+				{UserID: "b", Position: 2, Chosen: true, Reason: domain.ReasonRotation},
+				{UserID: "c", Position: 3, Reason: domain.ReasonSlotsFilled},
+				{UserID: "d", Position: 4, Chosen: true, Reason: domain.ReasonSeniorityRule},
+			},
+			wantCursor: "b",
+		},
+		{
+			name:          "parent reviewers are reused first",
+			members:       []domain.TeamMember{middle("a"), middle("b"), middle("c"), middle("d")},
+			author:        middle("a"),
+			parents:       []string{"d"},
+			wantReviewers: []string{"d", "b"},
+			wantDecisions: []decision{
+				{UserID: "a", Position: 1, Reason: domain.ReasonAuthor},
+				{UserID: "b", Position: 2, Chosen: true, Reason: domain.ReasonRotation},
+				{UserID: "c", Position: 3, Reason: domain.ReasonSlotsFilled},
+				{UserID: "d", Position: 4, Chosen: true, Reason: domain.ReasonParentReviewer},
+			},
+			wantCursor: "b",
+		},
+		{
+			name: "junior author with junior candidates only",
+			members: []domain.TeamMember{
+				member("a", domain.SeniorityJunior), member("b", domain.SeniorityJunior), member("c", domain.SeniorityJunior),
+			},
+			author:        member("a", domain.SeniorityJunior),
+			wantReviewers: nil,
+			wantDecisions: []decision{
+				{UserID: "a", Position: 1, Reason: domain.ReasonAuthor},
+				{UserID: "b", Position: 2, Reason: domain.ReasonJuniorOnly},
+				{UserID: "c", Position: 3, Reason: domain.ReasonJuniorOnly},
+			},
+			wantCursor: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := &domain.Team{TeamName: "backend", Members: tt.members}
+			req := assignmentRequest{author: &tt.author, coAuthors: tt.coAuthors, parentReviewers: tt.parents}
+
+			plan := buildAssignmentPlan(team, tt.state, req)
+			if !slices.Equal(plan.Reviewers, tt.wantReviewers) {
+				t.Errorf("Reviewers = %v, want %v", plan.Reviewers, tt.wantReviewers)
+			}
+			if !slices.Equal(plan.Decisions, tt.wantDecisions) {
+				t.Errorf("Decisions = %+v, want %+v", plan.Decisions, tt.wantDecisions)
+			}
+
+			cursor, ok := nextCursor(plan)
+			if cursor != tt.wantCursor || ok != (tt.wantCursor != "") {
+				t.Errorf("nextCursor() = (%q, %v), want %q", cursor, ok, tt.wantCursor)
+			}
+		})
+	}
+}
@@ -2,24 +2,36 @@ package service
 
 import (
 	"context"
+	"errors"
 	"pull_requests_service/internal/domain"
 	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/tracing"
+	"time"
 )
 
 type TeamService struct {
-	teamRepo domain.TeamRepository
-	userRepo domain.UserRepository
+	teamRepo      domain.TeamRepository
+	userRepo      domain.UserRepository
+	exclusionRepo domain.ReviewerExclusionRepository
 }
 
-func NewTeamService(teamRepo domain.TeamRepository, userRepo domain.UserRepository) *TeamService {
+func NewTeamService(
+	teamRepo domain.TeamRepository,
+	userRepo domain.UserRepository,
+	exclusionRepo domain.ReviewerExclusionRepository,
+) *TeamService {
 	return &TeamService{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
+		teamRepo:      teamRepo,
+		userRepo:      userRepo,
+		exclusionRepo: exclusionRepo,
 	}
 }
 
 func (s *TeamService) AddTeam(ctx context.Context, req dto.AddTeamRequest) (*domain.Team, error) {
-	exists, err := s.teamRepo.TeamExists(req.TeamName)
+	ctx, span := tracing.Start(ctx, "TeamService.AddTeam")
+	defer span.End()
+
+	exists, err := s.teamRepo.TeamExists(ctx, req.TeamName)
 	if err != nil {
 		return nil, err
 	}
@@ -33,28 +45,145 @@ func (s *TeamService) AddTeam(ctx context.Context, req dto.AddTeamRequest) (*dom
 	}
 
 	for i, member := range req.Members {
+		seniority, err := memberSeniority(ctx, s.userRepo, member.UserID, member.Seniority)
+		if err != nil {
+			return nil, err
+		}
+		if member.ReviewCapacity < 0 {
+			return nil, domain.ErrInvalidCapacity
+		}
+
 		team.Members[i] = domain.TeamMember{
-			UserID:   member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
+			UserID:         member.UserID,
+			Username:       member.Username,
+			IsActive:       member.IsActive,
+			Seniority:      seniority,
+			ReviewCapacity: member.ReviewCapacity,
 		}
 
-		if err := s.userRepo.CreateOrUpdateUser(&team.Members[i]); err != nil {
+		if err := s.userRepo.CreateOrUpdateUser(ctx, &team.Members[i]); err != nil {
 			return nil, err
 		}
 	}
 
-	if err := s.teamRepo.CreateTeam(team); err != nil {
+	if err := s.teamRepo.CreateTeam(ctx, team); err != nil {
 		return nil, err
 	}
 
 	return team, nil
 }
 
+// memberSeniority resolves the seniority of a member being added. When the
+// request leaves it out an existing user keeps the seniority they have, so
+// re-posting a team does not demote its seniors; new users get MIDDLE.
+func memberSeniority(ctx context.Context, userRepo domain.UserRepository, userID, requested string) (domain.Seniority, error) {
+	if requested != "" {
+		seniority := domain.Seniority(requested)
+		if !seniority.IsValid() {
+			return "", domain.ErrInvalidSeniority
+		}
+		return seniority, nil
+	}
+
+	user, err := userRepo.GetUser(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.SeniorityMiddle, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return user.Seniority, nil
+}
+
 func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
-	team, err := s.teamRepo.GetTeam(teamName)
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeam")
+	defer span.End()
+
+	team, err := s.teamRepo.GetTeam(ctx, teamName)
 	if err != nil {
 		return nil, err
 	}
 	return team, nil
 }
+
+func (s *TeamService) AddReviewerExclusion(ctx context.Context, req dto.ReviewerExclusionRequest) (*domain.ReviewerExclusion, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.AddReviewerExclusion")
+	defer span.End()
+
+	exclusion, err := s.reviewerExclusion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.exclusionRepo.AddExclusion(ctx, *exclusion); err != nil {
+		return nil, err
+	}
+
+	return exclusion, nil
+}
+
+func (s *TeamService) RemoveReviewerExclusion(ctx context.Context, req dto.ReviewerExclusionRequest) (*domain.ReviewerExclusion, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.RemoveReviewerExclusion")
+	defer span.End()
+
+	exclusion, err := s.reviewerExclusion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.exclusionRepo.RemoveExclusion(ctx, *exclusion); err != nil {
+		return nil, err
+	}
+
+	return exclusion, nil
+}
+
+func (s *TeamService) GetReviewerExclusions(ctx context.Context, teamName string) ([]domain.ReviewerExclusion, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetReviewerExclusions")
+	defer span.End()
+
+	exists, err := s.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return s.exclusionRepo.GetExclusions(ctx, teamName)
+}
+
+func (s *TeamService) GetTeamLoad(ctx context.Context, teamName string) ([]domain.MemberLoad, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeamLoad")
+	defer span.End()
+
+	now := time.Now().UTC()
+	return s.teamRepo.GetTeamLoad(ctx, teamName, now.AddDate(0, 0, -7), now.AddDate(0, 0, -30))
+}
+
+func (s *TeamService) reviewerExclusion(ctx context.Context, req dto.ReviewerExclusionRequest) (*domain.ReviewerExclusion, error) {
+	if req.UserID == req.OtherUserID {
+		return nil, domain.ErrInvalidExclusion
+	}
+
+	exists, err := s.teamRepo.TeamExists(ctx, req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	for _, userID := range []string{req.UserID, req.OtherUserID} {
+		teamName, err := s.userRepo.GetUserTeam(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if teamName != req.TeamName {
+			return nil, domain.ErrUserNotFound
+		}
+	}
+
+	exclusion := domain.NewReviewerExclusion(req.TeamName, req.UserID, req.OtherUserID)
+	return &exclusion, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"pull_requests_service/internal/auth"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"pull_requests_service/internal/tracing"
+	"time"
+)
+
+type TokenService struct {
+	tokenRepo      domain.TokenRepository
+	userRepo       domain.UserRepository
+	adminTokenHash string
+}
+
+// NewTokenService creates the token service. adminToken is the bootstrap
+// admin token from the configuration and may be empty to disable it.
+func NewTokenService(tokenRepo domain.TokenRepository, userRepo domain.UserRepository, adminToken string) *TokenService {
+	s := &TokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+	}
+	if adminToken != "" {
+		s.adminTokenHash = auth.HashToken(adminToken)
+	}
+	return s
+}
+
+func (s *TokenService) Authenticate(ctx context.Context, token string) (*auth.Identity, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.Authenticate")
+	defer span.End()
+
+	if token == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	tokenHash := auth.HashToken(token)
+	if s.adminTokenHash != "" && subtle.ConstantTimeCompare([]byte(tokenHash), []byte(s.adminTokenHash)) == 1 {
+		return &auth.Identity{Name: "bootstrap", Role: domain.RoleAdmin}, nil
+	}
+
+	apiToken, err := s.tokenRepo.GetTokenByHash(ctx, tokenHash)
+	if errors.Is(err, domain.ErrTokenNotFound) {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if apiToken.RevokedAt != nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return &auth.Identity{
+		TokenID: apiToken.TokenID,
+		Name:    apiToken.Name,
+		UserID:  apiToken.UserID,
+		Role:    apiToken.Role,
+	}, nil
+}
+
+// CreateToken issues a new token and returns it in plain text together with
+// its stored description. The plain text is not kept anywhere.
+func (s *TokenService) CreateToken(ctx context.Context, req dto.CreateTokenRequest) (string, *domain.APIToken, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.CreateToken")
+	defer span.End()
+
+	role := domain.Role(req.Role)
+	if !role.IsValid() {
+		return "", nil, domain.ErrInvalidRole
+	}
+
+	if req.UserID != "" {
+		if _, err := s.userRepo.GetUser(ctx, req.UserID); err != nil {
+			return "", nil, err
+		}
+	}
+
+	token, err := auth.GenerateToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	apiToken := &domain.APIToken{
+		Name:      req.Name,
+		Role:      role,
+		UserID:    req.UserID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.tokenRepo.CreateToken(ctx, apiToken, auth.HashToken(token)); err != nil {
+		return "", nil, err
+	}
+
+	slog.InfoContext(ctx, "API token created",
+		"token_id", apiToken.TokenID, "name", apiToken.Name, "role", apiToken.Role, "actor", auth.ActorFromContext(ctx))
+	return token, apiToken, nil
+}
+
+func (s *TokenService) RevokeToken(ctx context.Context, tokenID int64) error {
+	ctx, span := tracing.Start(ctx, "TokenService.RevokeToken")
+	defer span.End()
+
+	if err := s.tokenRepo.RevokeToken(ctx, tokenID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "API token revoked", "token_id", tokenID, "actor", auth.ActorFromContext(ctx))
+	return nil
+}
+
+func (s *TokenService) ListTokens(ctx context.Context) ([]domain.APIToken, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.ListTokens")
+	defer span.End()
+
+	return s.tokenRepo.ListTokens(ctx)
+}
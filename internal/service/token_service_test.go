@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pull_requests_service/internal/auth"
+	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/dto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryTokens is a TokenRepository keeping tokens by their hash.
+type memoryTokens struct {
+	byHash map[string]*domain.APIToken
+	nextID int64
+	err    error
+}
+
+func newMemoryTokens() *memoryTokens {
+	return &memoryTokens{byHash: make(map[string]*domain.APIToken)}
+}
+
+func (m *memoryTokens) CreateToken(ctx context.Context, token *domain.APIToken, tokenHash string) error {
+	m.nextID++
+	token.TokenID = m.nextID
+	stored := *token
+	m.byHash[tokenHash] = &stored
+	return nil
+}
+
+func (m *memoryTokens) GetTokenByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	token, ok := m.byHash[tokenHash]
+	if !ok {
+		return nil, domain.ErrTokenNotFound
+	}
+	stored := *token
+	return &stored, nil
+}
+
+func (m *memoryTokens) RevokeToken(ctx context.Context, tokenID int64) error {
+	for _, token := range m.byHash {
+		if token.TokenID == tokenID && token.RevokedAt == nil {
+			now := time.Now()
+			token.RevokedAt = &now
+			return nil
+		}
+	}
+	return domain.ErrTokenNotFound
+}
+
+func (m *memoryTokens) ListTokens(ctx context.Context) ([]domain.APIToken, error) {
+	var tokens []domain.APIToken
+	for _, token := range m.byHash {
+		tokens = append(tokens, *token)
+	}
+	return tokens, nil
+}
+
+// knownUsers is a UserRepository that only answers GetUser.
+type knownUsers struct {
+	domain.UserRepository
+	ids []string
+}
+
+func (k knownUsers) GetUser(ctx context.Context, userID string) (*domain.TeamMember, error) {
+	if contains(k.ids, userID) {
+		return &domain.TeamMember{UserID: userID}, nil
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func TestTokenServiceAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	tokens := newMemoryTokens()
+	s := NewTokenService(tokens, knownUsers{ids: []string{"u1"}}, "bootstrap-secret")
+
+	ciToken, _, err := s.CreateToken(ctx, dto.CreateTokenRequest{Name: "ci", Role: "user"})
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	aliceToken, alice, err := s.CreateToken(ctx, dto.CreateTokenRequest{Name: "alice", Role: "admin", UserID: "u1"})
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	revokedToken, revoked, err := s.CreateToken(ctx, dto.CreateTokenRequest{Name: "old", Role: "user"})
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	if err := s.RevokeToken(ctx, revoked.TokenID); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *auth.Identity
+		wantErr error
+	}{
+		{name: "bootstrap admin token", token: "bootstrap-secret", want: &auth.Identity{Name: "bootstrap", Role: domain.RoleAdmin}},
+		{name: "issued token", token: ciToken, want: &auth.Identity{TokenID: 1, Name: "ci", Role: domain.RoleUser}},
+		{
+			name:  "token bound to a user",
+			token: aliceToken,
+			want:  &auth.Identity{TokenID: alice.TokenID, Name: "alice", UserID: "u1", Role: domain.RoleAdmin},
+		},
+		{name: "revoked token", token: revokedToken, wantErr: domain.ErrUnauthorized},
+		{name: "unknown token", token: "prs_made-up", wantErr: domain.ErrUnauthorized},
+		{name: "no token", token: "", wantErr: domain.ErrUnauthorized},
+		{name: "token with extra characters", token: ciToken + " ", wantErr: domain.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Authenticate(ctx, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want != nil && *got != *tt.want {
+				t.Errorf("Authenticate() = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenServiceWithoutBootstrapToken(t *testing.T) {
+	s := NewTokenService(newMemoryTokens(), knownUsers{}, "")
+
+	// An empty bootstrap token must not turn the empty hash into an admin.
+	for _, token := range []string{"", "bootstrap-secret"} {
+		if _, err := s.Authenticate(context.Background(), token); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Errorf("Authenticate(%q) error = %v, want ErrUnauthorized", token, err)
+		}
+	}
+}
+
+func TestTokenServiceAuthenticateRepositoryError(t *testing.T) {
+	tokens := newMemoryTokens()
+	tokens.err = errors.New("connection refused")
+	s := NewTokenService(tokens, knownUsers{}, "")
+
+	// A database failure is not the caller's fault and must not read as 401.
+	_, err := s.Authenticate(context.Background(), "prs_anything")
+	if err == nil || errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("Authenticate() error = %v, want the repository error", err)
+	}
+}
+
+func TestTokenServiceCreateToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.CreateTokenRequest
+		wantErr error
+	}{
+		{name: "user token", req: dto.CreateTokenRequest{Name: "ci", Role: "user"}},
+		{name: "admin token bound to a user", req: dto.CreateTokenRequest{Name: "alice", Role: "admin", UserID: "u1"}},
+		{name: "unknown role", req: dto.CreateTokenRequest{Name: "ci", Role: "owner"}, wantErr: domain.ErrInvalidRole},
+		{name: "unknown user", req: dto.CreateTokenRequest{Name: "ci", Role: "user", UserID: "u9"}, wantErr: domain.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := newMemoryTokens()
+			s := NewTokenService(tokens, knownUsers{ids: []string{"u1"}}, "")
+
+			token, apiToken, err := s.CreateToken(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateToken() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(tokens.byHash) != 0 {
+					t.Errorf("a rejected token was stored")
+				}
+				return
+			}
+
+			if !strings.HasPrefix(token, "prs_") {
+				t.Errorf("token %q lacks the prs_ prefix", token)
+			}
+			stored, ok := tokens.byHash[auth.HashToken(token)]
+			if !ok {
+				t.Fatalf("token is not stored under its hash")
+			}
+			if _, ok := tokens.byHash[token]; ok {
+				t.Errorf("token is stored in plain text")
+			}
+			if stored.Role != domain.Role(tt.req.Role) || stored.UserID != tt.req.UserID || apiToken.TokenID != stored.TokenID {
+				t.Errorf("stored %+v, returned %+v for request %+v", *stored, *apiToken, tt.req)
+			}
+		})
+	}
+}
+
+func TestTokenServiceRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	s := NewTokenService(newMemoryTokens(), knownUsers{}, "")
+
+	token, apiToken, err := s.CreateToken(ctx, dto.CreateTokenRequest{Name: "ci", Role: "user"})
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	if _, err := s.Authenticate(ctx, token); err != nil {
+		t.Fatalf("Authenticate() before revoking error = %v", err)
+	}
+
+	if err := s.RevokeToken(ctx, apiToken.TokenID); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if _, err := s.Authenticate(ctx, token); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("Authenticate() after revoking error = %v, want ErrUnauthorized", err)
+	}
+	if err := s.RevokeToken(ctx, apiToken.TokenID); !errors.Is(err, domain.ErrTokenNotFound) {
+		t.Errorf("second RevokeToken() error = %v, want ErrTokenNotFound", err)
+	}
+}
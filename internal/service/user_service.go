@@ -2,33 +2,59 @@ package service
 
 import (
 	"context"
+	"pull_requests_service/internal/auth"
 	"pull_requests_service/internal/domain"
+	"pull_requests_service/internal/tracing"
+	"time"
 )
 
 type UserService struct {
-	userRepo domain.UserRepository
-	prRepo   domain.PRRepository
+	userRepo   domain.UserRepository
+	prRepo     domain.PRRepository
+	transactor domain.Transactor
 }
 
-func NewUserService(userRepo domain.UserRepository, prRepo domain.PRRepository) *UserService {
+func NewUserService(userRepo domain.UserRepository, prRepo domain.PRRepository, transactor domain.Transactor) *UserService {
 	return &UserService{
-		userRepo: userRepo,
-		prRepo:   prRepo,
+		userRepo:   userRepo,
+		prRepo:     prRepo,
+		transactor: transactor,
 	}
 }
 
+// SetUserActive changes the user's activity and records who changed it.
+// Setting the value the user already has changes nothing.
 func (s *UserService) SetUserActive(ctx context.Context, userID string, isActive bool) (*domain.TeamMember, string, error) {
-	user, err := s.userRepo.GetUser(userID)
+	ctx, span := tracing.Start(ctx, "UserService.SetUserActive")
+	defer span.End()
+
+	user, err := s.userRepo.GetUser(ctx, userID)
 	if err != nil {
 		return nil, "", err
 	}
 
-	teamName, err := s.userRepo.GetUserTeam(userID)
+	teamName, err := s.userRepo.GetUserTeam(ctx, userID)
 	if err != nil {
 		return nil, "", err
 	}
 
-	if err := s.userRepo.SetUserActive(userID, isActive); err != nil {
+	if user.IsActive == isActive {
+		return user, teamName, nil
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.SetUserActive(ctx, userID, isActive); err != nil {
+			return err
+		}
+		return s.userRepo.RecordActivityChange(ctx, domain.ActivityChange{
+			UserID:    userID,
+			TeamName:  teamName,
+			IsActive:  isActive,
+			Actor:     auth.ActorFromContext(ctx),
+			CreatedAt: time.Now().UTC(),
+		})
+	})
+	if err != nil {
 		return nil, "", err
 	}
 
@@ -37,11 +63,14 @@ func (s *UserService) SetUserActive(ctx context.Context, userID string, isActive
 }
 
 func (s *UserService) GetUserReviews(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
-	if _, err := s.userRepo.GetUser(userID); err != nil {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserReviews")
+	defer span.End()
+
+	if _, err := s.userRepo.GetUser(ctx, userID); err != nil {
 		return nil, err
 	}
 
-	prs, err := s.prRepo.GetPRsByReviewer(userID)
+	prs, err := s.prRepo.GetPRsByReviewer(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,70 @@
+// Package tracing sets up OpenTelemetry tracing and starts the spans of the
+// service layer. Trace context is propagated with W3C traceparent headers.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const ServiceName = "pull_requests_service"
+
+// Setup installs the global tracer provider and propagator. exporter is
+// none, stdout or otlp; the OTLP exporter is configured with the standard
+// OTEL_EXPORTER_OTLP_* environment variables. The returned function flushes
+// pending spans and must be called before exiting.
+func Setup(ctx context.Context, exporter string, sampleRatio float64) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "otlp":
+		spanExporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s exporter: %w", exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(ServiceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(ServiceName)
+}
+
+// Start starts a span for a service call.
+func Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name)
+}
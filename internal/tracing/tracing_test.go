@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// restoreGlobals puts back the global provider and propagator that Setup
+// replaces.
+func restoreGlobals(t *testing.T) {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		exporter string
+		wantErr  string
+	}{
+		{exporter: "none"},
+		{exporter: "stdout"},
+		{exporter: "jaeger", wantErr: `unknown tracing exporter "jaeger"`},
+		{exporter: "", wantErr: `unknown tracing exporter ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exporter, func(t *testing.T) {
+			restoreGlobals(t)
+
+			shutdown, err := Setup(context.Background(), tt.exporter, 1)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Setup() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Setup() error = %v", err)
+			}
+			if err := shutdown(context.Background()); err != nil {
+				t.Errorf("shutdown() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSetupInstallsTraceContextPropagator(t *testing.T) {
+	restoreGlobals(t)
+	if _, err := Setup(context.Background(), "none", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := otel.GetTextMapPropagator().Fields()
+	for _, want := range []string{"traceparent", "baggage"} {
+		found := false
+		for _, field := range fields {
+			found = found || field == want
+		}
+		if !found {
+			t.Errorf("propagator fields %v lack %s", fields, want)
+		}
+	}
+}
+
+func TestStartNestsSpans(t *testing.T) {
+	restoreGlobals(t)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctx, parent := Start(context.Background(), "PullRequestService.MergePR")
+	_, child := Start(ctx, "PullRequestRepository.UpdatePR")
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	gotChild, gotParent := spans[0], spans[1]
+	if gotChild.Parent().SpanID() != gotParent.SpanContext().SpanID() {
+		t.Errorf("%s is not a child of %s", gotChild.Name(), gotParent.Name())
+	}
+	if gotChild.SpanContext().TraceID() != gotParent.SpanContext().TraceID() {
+		t.Errorf("spans belong to different traces")
+	}
+	if got := gotParent.InstrumentationScope().Name; got != ServiceName {
+		t.Errorf("tracer name = %q, want %q", got, ServiceName)
+	}
+}
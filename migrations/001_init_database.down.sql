@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "pull_request";
+DROP TYPE IF EXISTS pull_request_status;
+DROP TABLE IF EXISTS "team_member";
+DROP TABLE IF EXISTS "team";
+DROP TABLE IF EXISTS "user";
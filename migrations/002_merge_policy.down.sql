@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "pull_request_review";
+DROP TYPE IF EXISTS review_state;
+DROP TABLE IF EXISTS "team_merge_policy_senior";
+DROP TABLE IF EXISTS "team_merge_policy";
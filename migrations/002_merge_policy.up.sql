@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS "team_merge_policy" (
+    "team_name" VARCHAR(256) NOT NULL PRIMARY KEY REFERENCES "team"("team_name") ON DELETE CASCADE,
+    "min_approvals" INTEGER NOT NULL DEFAULT 0,
+    "block_on_changes_requested" BOOLEAN NOT NULL DEFAULT FALSE,
+    "require_senior_approval" BOOLEAN NOT NULL DEFAULT FALSE,
+    "forbid_self_approval" BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS "team_merge_policy_senior" (
+    "team_name" VARCHAR(256) NOT NULL REFERENCES "team_merge_policy"("team_name") ON DELETE CASCADE,
+    "user_id" VARCHAR(256) NOT NULL REFERENCES "user"("user_id") ON DELETE CASCADE,
+    PRIMARY KEY ("team_name", "user_id")
+);
+
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'review_state') THEN
+        CREATE TYPE review_state AS ENUM ('APPROVED', 'CHANGES_REQUESTED');
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS "pull_request_review" (
+    "pull_request_id" VARCHAR(256) NOT NULL REFERENCES "pull_request"("pull_request_id") ON DELETE CASCADE,
+    "user_id" VARCHAR(256) NOT NULL REFERENCES "user"("user_id") ON DELETE CASCADE,
+    "state" "review_state" NOT NULL,
+    "submitted_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("pull_request_id", "user_id")
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "team_merge_policy_senior" (
+    "team_name" VARCHAR(256) NOT NULL REFERENCES "team_merge_policy"("team_name") ON DELETE CASCADE,
+    "user_id" VARCHAR(256) NOT NULL REFERENCES "user"("user_id") ON DELETE CASCADE,
+    PRIMARY KEY ("team_name", "user_id")
+);
+
+INSERT INTO "team_merge_policy_senior" ("team_name", "user_id")
+SELECT tm."team_name", u."user_id"
+FROM "user" u
+JOIN "team_member" tm ON tm."user_id" = u."user_id"
+JOIN "team_merge_policy" p ON p."team_name" = tm."team_name"
+WHERE u."seniority" = 'SENIOR'
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "seniority";
+DROP TYPE IF EXISTS seniority;
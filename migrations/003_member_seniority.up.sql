@@ -0,0 +1,13 @@
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'seniority') THEN
+        CREATE TYPE seniority AS ENUM ('JUNIOR', 'MIDDLE', 'SENIOR');
+    END IF;
+END $$;
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "seniority" "seniority" NOT NULL DEFAULT 'MIDDLE';
+
+UPDATE "user" SET "seniority" = 'SENIOR'
+WHERE "user_id" IN (SELECT "user_id" FROM "team_merge_policy_senior");
+
+DROP TABLE IF EXISTS "team_merge_policy_senior";
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "pull_request_co_author";
+DROP TABLE IF EXISTS "team_reviewer_exclusion";
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "team_reviewer_exclusion" (
+    "team_name" VARCHAR(256) NOT NULL REFERENCES "team"("team_name") ON DELETE CASCADE,
+    "user_id_1" VARCHAR(256) NOT NULL REFERENCES "user"("user_id") ON DELETE CASCADE,
+    "user_id_2" VARCHAR(256) NOT NULL REFERENCES "user"("user_id") ON DELETE CASCADE,
+    PRIMARY KEY ("team_name", "user_id_1", "user_id_2"),
+    CHECK ("user_id_1" < "user_id_2")
+);
+
+CREATE TABLE IF NOT EXISTS "pull_request_co_author" (
+    "pull_request_id" VARCHAR(256) NOT NULL REFERENCES "pull_request"("pull_request_id") ON DELETE CASCADE,
+    "user_id" VARCHAR(256) NOT NULL REFERENCES "user"("user_id") ON DELETE CASCADE,
+    PRIMARY KEY ("pull_request_id", "user_id")
+);
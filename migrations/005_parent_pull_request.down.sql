@@ -0,0 +1 @@
+ALTER TABLE "pull_request" DROP COLUMN IF EXISTS "parent_pull_request_id";
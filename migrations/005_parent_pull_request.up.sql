@@ -0,0 +1,3 @@
+ALTER TABLE "pull_request"
+    ADD COLUMN IF NOT EXISTS "parent_pull_request_id" VARCHAR(256)
+    REFERENCES "pull_request"("pull_request_id") ON DELETE SET NULL DEFAULT NULL;
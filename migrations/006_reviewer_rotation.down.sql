@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "pull_request_history";
+DROP TABLE IF EXISTS "team_rotation";
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "team_rotation" (
+    "team_name" VARCHAR(256) NOT NULL PRIMARY KEY REFERENCES "team"("team_name") ON DELETE CASCADE,
+    "last_user_id" VARCHAR(256) NOT NULL,
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS "pull_request_history" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "pull_request_id" VARCHAR(256) NOT NULL REFERENCES "pull_request"("pull_request_id") ON DELETE CASCADE,
+    "team_name" VARCHAR(256) NOT NULL,
+    "user_id" VARCHAR(256) NOT NULL,
+    "event" VARCHAR(32) NOT NULL,
+    "reason" VARCHAR(32) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "pull_request_history_pull_request_id_idx" ON "pull_request_history" ("pull_request_id");
+CREATE INDEX IF NOT EXISTS "pull_request_history_team_name_created_at_idx" ON "pull_request_history" ("team_name", "created_at");
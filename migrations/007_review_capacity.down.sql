@@ -0,0 +1 @@
+ALTER TABLE "user" DROP COLUMN IF EXISTS "review_capacity";
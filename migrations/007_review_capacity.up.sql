@@ -0,0 +1 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "review_capacity" INTEGER NOT NULL DEFAULT 0 CHECK ("review_capacity" >= 0);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS "team_member_user_id_idx";
+DROP INDEX IF EXISTS "pull_request_reviewer_2_idx";
+DROP INDEX IF EXISTS "pull_request_reviewer_1_idx";
+DROP INDEX IF EXISTS "pull_request_author_id_idx";
+DROP INDEX IF EXISTS "pull_request_status_idx";
+DROP INDEX IF EXISTS "pull_request_merged_at_idx";
+DROP INDEX IF EXISTS "pull_request_name_idx";
+DROP INDEX IF EXISTS "pull_request_created_at_idx";
+
+ALTER TABLE "pull_request" DROP COLUMN IF EXISTS "created_at";
@@ -0,0 +1,10 @@
+ALTER TABLE "pull_request" ADD COLUMN IF NOT EXISTS "created_at" TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS "pull_request_created_at_idx" ON "pull_request" ("created_at", "pull_request_id");
+CREATE INDEX IF NOT EXISTS "pull_request_name_idx" ON "pull_request" ("pull_request_name", "pull_request_id");
+CREATE INDEX IF NOT EXISTS "pull_request_merged_at_idx" ON "pull_request" ("mergedAt");
+CREATE INDEX IF NOT EXISTS "pull_request_status_idx" ON "pull_request" ("status");
+CREATE INDEX IF NOT EXISTS "pull_request_author_id_idx" ON "pull_request" ("author_id");
+CREATE INDEX IF NOT EXISTS "pull_request_reviewer_1_idx" ON "pull_request" ("reviewer_1");
+CREATE INDEX IF NOT EXISTS "pull_request_reviewer_2_idx" ON "pull_request" ("reviewer_2");
+CREATE INDEX IF NOT EXISTS "team_member_user_id_idx" ON "team_member" ("user_id");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "pull_request_assigned_at_idx";
+DROP INDEX IF EXISTS "pull_request_updated_at_idx";
+
+ALTER TABLE "pull_request" DROP COLUMN IF EXISTS "assigned_at";
+ALTER TABLE "pull_request" DROP COLUMN IF EXISTS "updated_at";
@@ -0,0 +1,8 @@
+ALTER TABLE "pull_request" ADD COLUMN IF NOT EXISTS "updated_at" TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE "pull_request" ADD COLUMN IF NOT EXISTS "assigned_at" TIMESTAMP DEFAULT NULL;
+
+UPDATE "pull_request" SET "updated_at" = COALESCE("mergedAt", "created_at");
+UPDATE "pull_request" SET "assigned_at" = "created_at" WHERE "reviewer_1" IS NOT NULL OR "reviewer_2" IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "pull_request_updated_at_idx" ON "pull_request" ("updated_at", "pull_request_id");
+CREATE INDEX IF NOT EXISTS "pull_request_assigned_at_idx" ON "pull_request" ("assigned_at");
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "api_token" (
+    "token_id" BIGSERIAL PRIMARY KEY,
+    "name" VARCHAR(256) NOT NULL,
+    "token_hash" CHAR(64) NOT NULL UNIQUE,
+    "role" VARCHAR(16) NOT NULL CHECK ("role" IN ('admin', 'user')),
+    "user_id" VARCHAR(256) REFERENCES "user"("user_id") ON DELETE CASCADE DEFAULT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "revoked_at" TIMESTAMP DEFAULT NULL
+);
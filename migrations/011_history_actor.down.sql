@@ -0,0 +1 @@
+ALTER TABLE "pull_request_history" DROP COLUMN IF EXISTS "actor";
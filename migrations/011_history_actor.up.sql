@@ -0,0 +1 @@
+ALTER TABLE "pull_request_history" ADD COLUMN IF NOT EXISTS "actor" VARCHAR(256) DEFAULT NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "pull_request_history_user_id_event_created_at_idx";
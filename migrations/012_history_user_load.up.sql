@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS "pull_request_history_user_id_event_created_at_idx" ON "pull_request_history" ("user_id", "event", "created_at");
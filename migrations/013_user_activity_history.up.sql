@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "user_activity_history" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" VARCHAR(256) NOT NULL REFERENCES "user"("user_id") ON DELETE CASCADE,
+    "team_name" VARCHAR(256) NOT NULL,
+    "is_active" BOOLEAN NOT NULL,
+    "actor" VARCHAR(256) DEFAULT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "user_activity_history_user_id_idx" ON "user_activity_history" ("user_id");
@@ -0,0 +1,7 @@
+ALTER TABLE "team_reviewer_exclusion" DROP CONSTRAINT IF EXISTS "team_reviewer_exclusion_check";
+
+UPDATE "team_reviewer_exclusion" SET "user_id_1" = "user_id_2", "user_id_2" = "user_id_1"
+WHERE "user_id_1" > "user_id_2";
+
+ALTER TABLE "team_reviewer_exclusion" ADD CONSTRAINT "team_reviewer_exclusion_check"
+    CHECK ("user_id_1" < "user_id_2");
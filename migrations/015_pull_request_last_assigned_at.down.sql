@@ -0,0 +1,2 @@
+ALTER INDEX IF EXISTS "pull_request_last_assigned_at_idx" RENAME TO "pull_request_assigned_at_idx";
+ALTER TABLE "pull_request" RENAME COLUMN "last_assigned_at" TO "assigned_at";
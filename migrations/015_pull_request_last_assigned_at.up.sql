@@ -0,0 +1,2 @@
+ALTER TABLE "pull_request" RENAME COLUMN "assigned_at" TO "last_assigned_at";
+ALTER INDEX IF EXISTS "pull_request_assigned_at_idx" RENAME TO "pull_request_last_assigned_at_idx";